@@ -0,0 +1,387 @@
+package manager
+
+import (
+	"math"
+	"sync"
+
+	"testServer/config"
+
+	pb "testServer/Messages"
+)
+
+// EntityKind tells players and monsters apart in the AOI grid
+type EntityKind int
+
+const (
+	EntityPlayer EntityKind = iota
+	EntityMonster
+)
+
+// EntityRef identifies an entity tracked by the AOI grid
+type EntityRef struct {
+	Kind      EntityKind
+	PlayerId  string
+	MonsterId int32
+}
+
+func PlayerRef(playerId string) EntityRef {
+	return EntityRef{Kind: EntityPlayer, PlayerId: playerId}
+}
+
+func MonsterRef(monsterId int32) EntityRef {
+	return EntityRef{Kind: EntityMonster, MonsterId: monsterId}
+}
+
+// AOIEvent tells Observer that Entity came into (Enter) or left its view radius.
+// X/Z are the entity's position when the event was generated.
+type AOIEvent struct {
+	Observer string
+	Entity   EntityRef
+	Enter    bool
+	X, Z     float32
+}
+
+type cellKey struct {
+	X, Z int32
+}
+
+type aoiEntity struct {
+	x, z float32
+	cell cellKey
+}
+
+// AOIManager is a uniform grid over the X/Z plane. Only players observe; each
+// player sees the entities within viewRadius of it.
+type AOIManager struct {
+	mu         sync.Mutex
+	cellSize   float32
+	viewRadius float32
+	cells      map[cellKey]map[EntityRef]struct{}
+	entities   map[EntityRef]*aoiEntity
+	// 플레이어별로 현재 보고 있는 엔티티와, 엔티티별로 그것을 보고 있는 플레이어 (역인덱스)
+	visible   map[string]map[EntityRef]struct{}
+	observers map[EntityRef]map[string]struct{}
+}
+
+var (
+	aoiManager     *AOIManager
+	aoiManagerOnce sync.Once
+)
+
+// GetAOIManager returns the AOIManager
+func GetAOIManager() *AOIManager {
+	aoiManagerOnce.Do(func() {
+		aoiManager = NewAOIManager(config.Default().World.ViewRadius)
+	})
+
+	return aoiManager
+}
+
+// NewAOIManager creates a grid whose cells are viewRadius wide, so a query only
+// has to look at the 3x3 cells around a position
+func NewAOIManager(viewRadius float32) *AOIManager {
+	return &AOIManager{
+		cellSize:   viewRadius,
+		viewRadius: viewRadius,
+		cells:      make(map[cellKey]map[EntityRef]struct{}),
+		entities:   make(map[EntityRef]*aoiEntity),
+		visible:    make(map[string]map[EntityRef]struct{}),
+		observers:  make(map[EntityRef]map[string]struct{}),
+	}
+}
+
+// Add places a new entity and returns the resulting enter events
+func (am *AOIManager) Add(ref EntityRef, x, z float32) []AOIEvent {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	if _, exists := am.entities[ref]; exists {
+		return am.move(ref, x, z)
+	}
+
+	entity := &aoiEntity{x: x, z: z, cell: am.cellOf(x, z)}
+	am.entities[ref] = entity
+	am.insertCell(ref, entity.cell)
+	if ref.Kind == EntityPlayer {
+		am.visible[ref.PlayerId] = make(map[EntityRef]struct{})
+	}
+	return am.update(ref, entity)
+}
+
+// Move updates an entity's position and returns the resulting enter/leave events
+func (am *AOIManager) Move(ref EntityRef, x, z float32) []AOIEvent {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	return am.move(ref, x, z)
+}
+
+// Remove deletes an entity and returns leave events for everyone who saw it
+func (am *AOIManager) Remove(ref EntityRef) []AOIEvent {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	entity, exists := am.entities[ref]
+	if !exists {
+		return nil
+	}
+
+	var events []AOIEvent
+	for observer := range am.observers[ref] {
+		am.unsee(observer, ref)
+		events = append(events, AOIEvent{Observer: observer, Entity: ref, Enter: false, X: entity.x, Z: entity.z})
+	}
+
+	// 사라지는 플레이어가 보고 있던 엔티티의 역인덱스도 정리한다
+	if ref.Kind == EntityPlayer {
+		for other := range am.visible[ref.PlayerId] {
+			am.unsee(ref.PlayerId, other)
+		}
+		delete(am.visible, ref.PlayerId)
+	}
+
+	am.removeCell(ref, entity.cell)
+	delete(am.entities, ref)
+	delete(am.observers, ref)
+	return events
+}
+
+// Observers returns the players that currently see ref
+func (am *AOIManager) Observers(ref EntityRef) []string {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	observers := make([]string, 0, len(am.observers[ref]))
+	for observer := range am.observers[ref] {
+		observers = append(observers, observer)
+	}
+	return observers
+}
+
+// Visible returns the entities a player currently sees, with their positions
+func (am *AOIManager) Visible(playerId string) []AOIEvent {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	seen := am.visible[playerId]
+	events := make([]AOIEvent, 0, len(seen))
+	for ref := range seen {
+		entity := am.entities[ref]
+		events = append(events, AOIEvent{Observer: playerId, Entity: ref, Enter: true, X: entity.x, Z: entity.z})
+	}
+	return events
+}
+
+func (am *AOIManager) move(ref EntityRef, x, z float32) []AOIEvent {
+	entity, exists := am.entities[ref]
+	if !exists {
+		return nil
+	}
+
+	entity.x, entity.z = x, z
+	if cell := am.cellOf(x, z); cell != entity.cell {
+		am.removeCell(ref, entity.cell)
+		entity.cell = cell
+		am.insertCell(ref, cell)
+	}
+	return am.update(ref, entity)
+}
+
+// update recomputes who sees ref, and what ref sees if it is a player. am.mu must be held.
+func (am *AOIManager) update(ref EntityRef, entity *aoiEntity) []AOIEvent {
+	var events []AOIEvent
+	nearby := am.query(ref, entity.x, entity.z)
+
+	// 시야에서 벗어난 플레이어들
+	for observer := range am.observers[ref] {
+		if _, near := nearby[PlayerRef(observer)]; !near {
+			am.unsee(observer, ref)
+			events = append(events, AOIEvent{Observer: observer, Entity: ref, Enter: false, X: entity.x, Z: entity.z})
+		}
+	}
+	// 새로 시야에 들어온 플레이어들
+	for other := range nearby {
+		if other.Kind != EntityPlayer {
+			continue
+		}
+		if _, saw := am.observers[ref][other.PlayerId]; !saw {
+			am.see(other.PlayerId, ref)
+			events = append(events, AOIEvent{Observer: other.PlayerId, Entity: ref, Enter: true, X: entity.x, Z: entity.z})
+		}
+	}
+
+	if ref.Kind != EntityPlayer {
+		return events
+	}
+
+	// 움직인 플레이어 자신의 시야를 갱신한다
+	for other := range am.visible[ref.PlayerId] {
+		if _, near := nearby[other]; !near {
+			am.unsee(ref.PlayerId, other)
+			o := am.entities[other]
+			events = append(events, AOIEvent{Observer: ref.PlayerId, Entity: other, Enter: false, X: o.x, Z: o.z})
+		}
+	}
+	for other := range nearby {
+		if _, saw := am.visible[ref.PlayerId][other]; !saw {
+			am.see(ref.PlayerId, other)
+			o := am.entities[other]
+			events = append(events, AOIEvent{Observer: ref.PlayerId, Entity: other, Enter: true, X: o.x, Z: o.z})
+		}
+	}
+	return events
+}
+
+// query returns every entity except self within the view radius of (x, z). am.mu must be held.
+func (am *AOIManager) query(self EntityRef, x, z float32) map[EntityRef]struct{} {
+	result := make(map[EntityRef]struct{})
+	center := am.cellOf(x, z)
+	radiusSq := am.viewRadius * am.viewRadius
+
+	for cx := center.X - 1; cx <= center.X+1; cx++ {
+		for cz := center.Z - 1; cz <= center.Z+1; cz++ {
+			for ref := range am.cells[cellKey{X: cx, Z: cz}] {
+				if ref == self {
+					continue
+				}
+				other := am.entities[ref]
+				dx, dz := other.x-x, other.z-z
+				if dx*dx+dz*dz <= radiusSq {
+					result[ref] = struct{}{}
+				}
+			}
+		}
+	}
+	return result
+}
+
+func (am *AOIManager) see(observer string, ref EntityRef) {
+	am.visible[observer][ref] = struct{}{}
+	watchers, exists := am.observers[ref]
+	if !exists {
+		watchers = make(map[string]struct{})
+		am.observers[ref] = watchers
+	}
+	watchers[observer] = struct{}{}
+}
+
+func (am *AOIManager) unsee(observer string, ref EntityRef) {
+	delete(am.visible[observer], ref)
+	delete(am.observers[ref], observer)
+}
+
+func (am *AOIManager) cellOf(x, z float32) cellKey {
+	return cellKey{
+		X: int32(math.Floor(float64(x / am.cellSize))),
+		Z: int32(math.Floor(float64(z / am.cellSize))),
+	}
+}
+
+func (am *AOIManager) insertCell(ref EntityRef, cell cellKey) {
+	members, exists := am.cells[cell]
+	if !exists {
+		members = make(map[EntityRef]struct{})
+		am.cells[cell] = members
+	}
+	members[ref] = struct{}{}
+}
+
+func (am *AOIManager) removeCell(ref EntityRef, cell cellKey) {
+	members := am.cells[cell]
+	delete(members, ref)
+	if len(members) == 0 {
+		delete(am.cells, cell)
+	}
+}
+
+// DeliverAOIEvents sends the spawn/despawn message for each event to its observer
+func DeliverAOIEvents(events []AOIEvent) {
+	pm := GetPlayerManager()
+	for _, event := range events {
+		observer, err := pm.GetPlayer(event.Observer)
+		if err != nil {
+			continue
+		}
+		if message := aoiEventMessage(event); message != nil {
+			observer.Send(message)
+		}
+	}
+}
+
+// observerMessage is a message addressed to the players that saw an entity when it was queued
+type observerMessage struct {
+	observers []string
+	msg       *pb.GameMessage
+}
+
+// messageForObservers addresses msg to the players that currently see ref. Nothing is
+// sent yet, so it can be called while a manager lock is held.
+func messageForObservers(ref EntityRef, msg *pb.GameMessage) observerMessage {
+	return observerMessage{observers: GetAOIManager().Observers(ref), msg: msg}
+}
+
+// deliverObserverMessages sends each message to its observers. It must be called without
+// any manager lock held, because a session with the block policy waits here for queue space.
+func deliverObserverMessages(messages []observerMessage) {
+	pm := GetPlayerManager()
+	for _, message := range messages {
+		if len(message.observers) == 0 {
+			continue
+		}
+		packet := GetNetManager().MakePacket(message.msg)
+		if packet == nil {
+			continue
+		}
+		for _, observer := range message.observers {
+			if p, err := pm.GetPlayer(observer); err == nil {
+				p.SendPacket(packet)
+			}
+		}
+	}
+}
+
+func aoiEventMessage(event AOIEvent) *pb.GameMessage {
+	switch event.Entity.Kind {
+	case EntityPlayer:
+		if !event.Enter {
+			return &pb.GameMessage{
+				Message: &pb.GameMessage_DespawnOtherPlayer{
+					DespawnOtherPlayer: &pb.DespawnOtherPlayer{PlayerId: event.Entity.PlayerId},
+				},
+			}
+		}
+		player, err := GetPlayerManager().GetPlayer(event.Entity.PlayerId)
+		if err != nil {
+			return nil
+		}
+		pos, rotationY := player.Transform()
+		return &pb.GameMessage{
+			Message: &pb.GameMessage_SpawnOtherPlayer{
+				SpawnOtherPlayer: &pb.SpawnOtherPlayer{
+					PlayerId:  player.Name,
+					X:         pos.X,
+					Y:         pos.Y,
+					Z:         pos.Z,
+					RotationY: rotationY,
+				},
+			},
+		}
+	case EntityMonster:
+		if !event.Enter {
+			return &pb.GameMessage{
+				Message: &pb.GameMessage_DespawnMonster{
+					DespawnMonster: &pb.DespawnMonster{MonsterId: event.Entity.MonsterId},
+				},
+			}
+		}
+		return &pb.GameMessage{
+			Message: &pb.GameMessage_SpawnMonster{
+				SpawnMonster: &pb.SpawnMonster{
+					X:         event.X,
+					Z:         event.Z,
+					MonsterId: event.Entity.MonsterId,
+				},
+			},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package manager
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// describe turns events into sorted "observer+entity" / "observer-entity" strings
+func describe(events []AOIEvent) []string {
+	described := make([]string, 0, len(events))
+	for _, event := range events {
+		entity := event.Entity.PlayerId
+		if event.Entity.Kind == EntityMonster {
+			entity = fmt.Sprintf("monster%d", event.Entity.MonsterId)
+		}
+		sign := "-"
+		if event.Enter {
+			sign = "+"
+		}
+		described = append(described, event.Observer+sign+entity)
+	}
+	slices.Sort(described)
+	return described
+}
+
+func expectEvents(t *testing.T, what string, got []AOIEvent, want ...string) {
+	t.Helper()
+	slices.Sort(want)
+	if described := describe(got); !slices.Equal(described, want) {
+		t.Fatalf("%s: events %v, want %v", what, described, want)
+	}
+}
+
+func TestAOIViewRadiusEdge(t *testing.T) {
+	am := NewAOIManager(10)
+	am.Add(PlayerRef("a"), 0, 0)
+
+	// 정확히 시야 반경에 있는 엔티티는 보이고, 조금이라도 벗어나면 보이지 않는다
+	expectEvents(t, "add at the radius", am.Add(MonsterRef(1), 6, 8), "a+monster1")
+	expectEvents(t, "add past the radius", am.Add(MonsterRef(2), 0, 10.01))
+	expectEvents(t, "step out", am.Move(MonsterRef(1), 6, 8.01), "a-monster1")
+	expectEvents(t, "step back in", am.Move(MonsterRef(1), -10, 0), "a+monster1")
+}
+
+func TestAOICrossingCells(t *testing.T) {
+	am := NewAOIManager(10)
+	am.Add(PlayerRef("near"), 5, 5)
+	am.Add(PlayerRef("far"), 25, 5)
+	am.Add(MonsterRef(1), 9.9, 5)
+
+	if cell := am.entities[MonsterRef(1)].cell; cell != (cellKey{X: 0, Z: 0}) {
+		t.Fatalf("monster starts in cell %v", cell)
+	}
+	// 칸 경계를 넘어도 계속 보고 있던 플레이어에게는 이벤트가 없고, 새로 가까워진 플레이어만 본다
+	expectEvents(t, "cross into the next cell", am.Move(MonsterRef(1), 15, 5), "far+monster1")
+	if cell := am.entities[MonsterRef(1)].cell; cell != (cellKey{X: 1, Z: 0}) {
+		t.Fatalf("monster moved to cell %v, want {1 0}", cell)
+	}
+	if _, stale := am.cells[cellKey{X: 0, Z: 0}][MonsterRef(1)]; stale {
+		t.Fatal("monster is still indexed in its old cell")
+	}
+
+	// 음수 좌표도 바닥 방향으로 칸을 나눈다
+	expectEvents(t, "cross zero", am.Move(MonsterRef(1), -0.5, 5), "far-monster1")
+	if cell := am.entities[MonsterRef(1)].cell; cell != (cellKey{X: -1, Z: 0}) {
+		t.Fatalf("monster at x=-0.5 is in cell %v, want {-1 0}", cell)
+	}
+	expectEvents(t, "several cells at once", am.Move(MonsterRef(1), 30, 5), "near-monster1", "far+monster1")
+}
+
+func TestAOIPlayerMove(t *testing.T) {
+	am := NewAOIManager(10)
+	am.Add(PlayerRef("a"), 0, 0)
+	am.Add(MonsterRef(1), 20, 0)
+	expectEvents(t, "second player joins", am.Add(PlayerRef("b"), 5, 0), "a+b", "b+a")
+
+	// 움직인 플레이어는 자기 시야의 변화를, 다른 플레이어는 그 플레이어의 출입을 받는다
+	expectEvents(t, "b walks towards the monster", am.Move(PlayerRef("b"), 15, 0), "a-b", "b-a", "b+monster1")
+	expectEvents(t, "b walks back", am.Move(PlayerRef("b"), 5, 0), "a+b", "b+a", "b-monster1")
+
+	if visible := describe(am.Visible("b")); !slices.Equal(visible, []string{"b+a"}) {
+		t.Fatalf("b sees %v, want only a", visible)
+	}
+	if observers := am.Observers(MonsterRef(1)); len(observers) != 0 {
+		t.Fatalf("monster still observed by %v", observers)
+	}
+}
+
+func TestAOIRemove(t *testing.T) {
+	am := NewAOIManager(10)
+	am.Add(PlayerRef("a"), 0, 0)
+	am.Add(PlayerRef("b"), 1, 0)
+	am.Add(MonsterRef(1), 2, 0)
+
+	expectEvents(t, "remove monster", am.Remove(MonsterRef(1)), "a-monster1", "b-monster1")
+	expectEvents(t, "remove player", am.Remove(PlayerRef("b")), "a-b")
+	expectEvents(t, "remove twice", am.Remove(PlayerRef("b")))
+
+	// 사라진 플레이어는 역인덱스와 격자 어디에도 남지 않아야 한다
+	for ref, watchers := range am.observers {
+		if _, stale := watchers["b"]; stale {
+			t.Fatalf("%v is still observed by removed player b", ref)
+		}
+	}
+	if _, stale := am.visible["b"]; stale {
+		t.Fatal("removed player still has a view")
+	}
+	if _, stale := am.observers[PlayerRef("b")]; stale {
+		t.Fatal("removed player still has observers")
+	}
+	for cell, members := range am.cells {
+		if _, stale := members[PlayerRef("b")]; stale {
+			t.Fatalf("removed player still indexed in cell %v", cell)
+		}
+	}
+	if visible := describe(am.Visible("a")); len(visible) != 0 {
+		t.Fatalf("a still sees %v", visible)
+	}
+
+	// 다시 들어오면 처음부터 보인다
+	expectEvents(t, "player returns", am.Add(PlayerRef("b"), 1, 0), "a+b", "b+a")
+}
@@ -0,0 +1,200 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"testServer/behavior"
+)
+
+var (
+	adminManager     *AdminManager
+	adminManagerOnce sync.Once
+)
+
+// 추적 시작 요청에 ticks가 없을 때 남기는 틱 수 (기본 틱 레이트로 5초)
+const defaultTraceTicks = 100
+
+// AdminManager serves JSON endpoints for operators and tooling. They are read-only
+// except for turning behavior tree tracing on and off.
+type AdminManager struct {
+	mux    *http.ServeMux
+	server *http.Server
+}
+
+// SessionInfo describes one connected session on the admin endpoint
+type SessionInfo struct {
+	ID            uint64  `json:"id"`
+	RemoteAddr    string  `json:"remote_addr"`
+	PlayerId      string  `json:"player_id"`
+	RTTMillis     float64 `json:"rtt_ms"`
+	SmoothedRTTMs float64 `json:"smoothed_rtt_ms"`
+	Dropped       uint64  `json:"dropped"`
+}
+
+// PlayerInfo describes one logged in player on the admin endpoint
+type PlayerInfo struct {
+	PlayerId   string  `json:"player_id"`
+	X          float32 `json:"x"`
+	Y          float32 `json:"y"`
+	Z          float32 `json:"z"`
+	Violations uint64  `json:"movement_violations"`
+}
+
+// ServerStats is the payload of the /stats endpoint
+type ServerStats struct {
+	Sessions        int           `json:"sessions"`
+	Players         int           `json:"players"`
+	UnknownMessages uint64        `json:"unknown_messages"`
+	Tick            TickStats     `json:"tick"`
+	Snapshots       SnapshotStats `json:"snapshots"`
+}
+
+// GetAdminManager returns the AdminManager
+func GetAdminManager() *AdminManager {
+	adminManagerOnce.Do(func() {
+		adminManager = &AdminManager{
+			mux: http.NewServeMux(),
+		}
+		adminManager.HandleFunc("/sessions", adminManager.handleSessions)
+		adminManager.HandleFunc("/players", adminManager.handlePlayers)
+		adminManager.HandleFunc("/monsters", adminManager.handleMonsters)
+		adminManager.HandleFunc("/monsters/trace", adminManager.handleMonsterTrace)
+		adminManager.HandleFunc("/stats", adminManager.handleStats)
+	})
+
+	return adminManager
+}
+
+// HandleFunc adds an admin endpoint. Must be called before Start.
+func (am *AdminManager) HandleFunc(pattern string, handler http.HandlerFunc) {
+	am.mux.HandleFunc(pattern, handler)
+}
+
+// Start listens on addr and serves the admin endpoints in the background
+func (am *AdminManager) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	am.server = &http.Server{Handler: am.mux}
+	go func() {
+		if err := am.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("Admin server stopped: %v", err)
+		}
+	}()
+	log.Printf("Admin endpoint is listening on %s", addr)
+	return nil
+}
+
+// Shutdown stops the admin server if it was started
+func (am *AdminManager) Shutdown(ctx context.Context) error {
+	if am.server == nil {
+		return nil
+	}
+	return am.server.Shutdown(ctx)
+}
+
+func (am *AdminManager) handleSessions(w http.ResponseWriter, r *http.Request) {
+	sessions := GetSessionManager().ListSessions()
+	infos := make([]SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		rtt, smoothed := session.RTT()
+		infos = append(infos, SessionInfo{
+			ID:            session.ID(),
+			RemoteAddr:    session.RemoteAddr().String(),
+			PlayerId:      session.PlayerId(),
+			RTTMillis:     float64(rtt.Microseconds()) / 1000,
+			SmoothedRTTMs: float64(smoothed.Microseconds()) / 1000,
+			Dropped:       session.Dropped(),
+		})
+	}
+	writeJSON(w, infos)
+}
+
+func (am *AdminManager) handlePlayers(w http.ResponseWriter, r *http.Request) {
+	players := GetPlayerManager().ListPlayers()
+	infos := make([]PlayerInfo, 0, len(players))
+	for _, player := range players {
+		pos := player.Position()
+		infos = append(infos, PlayerInfo{
+			PlayerId:   player.Name,
+			X:          pos.X,
+			Y:          pos.Y,
+			Z:          pos.Z,
+			Violations: player.Violations(),
+		})
+	}
+	writeJSON(w, infos)
+}
+
+func (am *AdminManager) handleMonsters(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, GetMonsterManager().DebugInfo())
+}
+
+// handleMonsterTrace controls the behavior tree trace of the monster given by ?id=.
+// POST starts tracing the last ?ticks= ticks, GET returns them and DELETE stops tracing.
+func (am *AdminManager) handleMonsterTrace(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "id must be a monster id", http.StatusBadRequest)
+		return
+	}
+
+	mm := GetMonsterManager()
+	switch r.Method {
+	case http.MethodGet:
+		var ticks []behavior.TickTrace
+		ticks, err = mm.MonsterTrace(int32(id))
+		if err == nil {
+			writeJSON(w, ticks)
+		}
+	case http.MethodPost:
+		ticks := defaultTraceTicks
+		if value := r.URL.Query().Get("ticks"); value != "" {
+			if ticks, err = strconv.Atoi(value); err != nil || ticks <= 0 {
+				http.Error(w, "ticks must be a positive number", http.StatusBadRequest)
+				return
+			}
+		}
+		err = mm.TraceMonster(int32(id), ticks)
+	case http.MethodDelete:
+		err = mm.TraceMonster(int32(id), 0)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if errors.Is(err, ErrMonsterNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (am *AdminManager) handleStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, ServerStats{
+		Sessions:        len(GetSessionManager().ListSessions()),
+		Players:         len(GetPlayerManager().ListPlayers()),
+		UnknownMessages: GetDispatcher().UnknownCount(),
+		Tick:            GetWorld().Stats(),
+		Snapshots:       GetSnapshotManager().Stats(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		log.Printf("Failed to write admin response: %v", err)
+	}
+}
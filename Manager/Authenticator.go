@@ -0,0 +1,177 @@
+package manager
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "testServer/Messages"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+)
+
+// Authenticator verifies a LoginMessage and returns the player id it proves
+type Authenticator interface {
+	Authenticate(login *pb.LoginMessage) (playerId string, err error)
+}
+
+// denyAuthenticator rejects every login. Used until an Authenticator is configured.
+type denyAuthenticator struct{}
+
+func (denyAuthenticator) Authenticate(login *pb.LoginMessage) (string, error) {
+	return "", ErrInvalidCredentials
+}
+
+// 계정 파일 형식: {"users": [{"player_id": "...", "password_hash": "<bcrypt>"}]}
+// bcrypt 해시는 솔트와 비용을 함께 담고 있다. 해시는 go run ./cmd/hashpassword 로 만든다.
+type usersFile struct {
+	Users []fileUser `json:"users"`
+}
+
+type fileUser struct {
+	PlayerId     string `json:"player_id"`
+	PasswordHash string `json:"password_hash"`
+}
+
+// 없는 계정에 대해서도 비교 비용을 치르기 위한 해시 ("unused"의 bcrypt 해시)
+var dummyPasswordHash = []byte("$2a$10$aZ4R3rjSY6dO3RmTrA9rQuSkihUQxxdPOvN4gOi16TVRjPGluYB1e")
+
+// FileAuthenticator checks passwords against a JSON accounts file loaded at startup
+type FileAuthenticator struct {
+	users map[string]fileUser
+}
+
+// NewFileAuthenticator loads the accounts file at path
+func NewFileAuthenticator(path string) (*FileAuthenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("open users file: %w", err)
+	}
+
+	var file usersFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decode users file %s: %w", path, err)
+	}
+
+	fa := &FileAuthenticator{users: make(map[string]fileUser, len(file.Users))}
+	for i, user := range file.Users {
+		if user.PlayerId == "" || user.PasswordHash == "" {
+			return nil, fmt.Errorf("users file %s: entry %d needs player_id and password_hash", path, i)
+		}
+		if _, err := bcrypt.Cost([]byte(user.PasswordHash)); err != nil {
+			return nil, fmt.Errorf("users file %s: password_hash of %q is not a bcrypt hash: %w", path, user.PlayerId, err)
+		}
+		if _, exists := fa.users[user.PlayerId]; exists {
+			return nil, fmt.Errorf("users file %s: duplicate player_id %q", path, user.PlayerId)
+		}
+		fa.users[user.PlayerId] = user
+	}
+	return fa, nil
+}
+
+func (fa *FileAuthenticator) Authenticate(login *pb.LoginMessage) (string, error) {
+	user, exists := fa.users[login.PlayerId]
+	if !exists {
+		// 존재하지 않는 계정도 같은 비용을 들여 타이밍으로 구분되지 않게 한다
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(login.Password))
+		return "", ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(login.Password)); err != nil {
+		return "", ErrInvalidCredentials
+	}
+	return user.PlayerId, nil
+}
+
+// HashPassword returns the bcrypt hash of password, the format stored in the users file
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// TokenSigner issues and verifies HMAC-signed session tokens of the form
+// base64(playerId).expiryUnix.base64(signature)
+type TokenSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewTokenSigner creates a signer. An empty secret generates a random one,
+// so tokens don't survive a restart.
+func NewTokenSigner(secret string, ttl time.Duration) (*TokenSigner, error) {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+	return &TokenSigner{secret: key, ttl: ttl}, nil
+}
+
+// Issue returns a token proving playerId until the signer's TTL passes
+func (ts *TokenSigner) Issue(playerId string) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(playerId)) + "." +
+		strconv.FormatInt(time.Now().Add(ts.ttl).Unix(), 10)
+	return payload + "." + ts.sign(payload)
+}
+
+// Verify returns the player id inside a valid, unexpired token
+func (ts *TokenSigner) Verify(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", ErrInvalidToken
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(ts.sign(payload)), []byte(parts[2])) {
+		return "", ErrInvalidToken
+	}
+
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expiry {
+		return "", ErrInvalidToken
+	}
+
+	playerId, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || len(playerId) == 0 {
+		return "", ErrInvalidToken
+	}
+	return string(playerId), nil
+}
+
+func (ts *TokenSigner) sign(payload string) string {
+	mac := hmac.New(sha256.New, ts.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// TokenAuthenticator accepts a signed token when the login carries one and
+// otherwise defers to Fallback for credential checks
+type TokenAuthenticator struct {
+	Signer   *TokenSigner
+	Fallback Authenticator
+}
+
+func (ta *TokenAuthenticator) Authenticate(login *pb.LoginMessage) (string, error) {
+	if login.Token != "" {
+		return ta.Signer.Verify(login.Token)
+	}
+	return ta.Fallback.Authenticate(login)
+}
@@ -0,0 +1,98 @@
+package manager
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "testServer/Messages"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestFileAuthenticator(t *testing.T) {
+	// 테스트가 느려지지 않도록 가장 낮은 비용으로 해시한다
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "users.json")
+	users := `{"users": [{"player_id": "alice", "password_hash": "` + string(hash) + `"}]}`
+	if err := os.WriteFile(path, []byte(users), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fa, err := NewFileAuthenticator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		login    *pb.LoginMessage
+		wantErr  error
+		wantName string
+	}{
+		{"right password", &pb.LoginMessage{PlayerId: "alice", Password: "secret"}, nil, "alice"},
+		{"wrong password", &pb.LoginMessage{PlayerId: "alice", Password: "guess"}, ErrInvalidCredentials, ""},
+		{"empty password", &pb.LoginMessage{PlayerId: "alice"}, ErrInvalidCredentials, ""},
+		{"unknown user", &pb.LoginMessage{PlayerId: "mallory", Password: "secret"}, ErrInvalidCredentials, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playerId, err := fa.Authenticate(tt.login)
+			if !errors.Is(err, tt.wantErr) || playerId != tt.wantName {
+				t.Fatalf("Authenticate = %q, %v, want %q, %v", playerId, err, tt.wantName, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFileAuthenticatorRejectsPlainHashes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	users := `{"users": [{"player_id": "alice", "password_hash": "bcf1ddfda388f011e3a4cc3287c3d448"}]}`
+	if err := os.WriteFile(path, []byte(users), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileAuthenticator(path); err == nil || !strings.Contains(err.Error(), "bcrypt") {
+		t.Fatalf("loading a non-bcrypt hash gave %v, want a bcrypt error", err)
+	}
+}
+
+func TestTokenSignerVerify(t *testing.T) {
+	signer, err := NewTokenSigner("secret", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := signer.Issue("alice")
+	if playerId, err := signer.Verify(token); err != nil || playerId != "alice" {
+		t.Fatalf("Verify(valid token) = %q, %v", playerId, err)
+	}
+
+	parts := strings.Split(token, ".")
+	expired, _ := NewTokenSigner("secret", -time.Minute)
+	otherKey, _ := NewTokenSigner("other secret", time.Hour)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"too few parts", parts[0] + "." + parts[1]},
+		{"too many parts", token + ".extra"},
+		{"other player", "Ym9i." + parts[1] + "." + parts[2]},
+		{"extended expiry", parts[0] + ".9999999999." + parts[2]},
+		{"bad signature", parts[0] + "." + parts[1] + ".AAAA"},
+		{"signed by another key", otherKey.Issue("alice")},
+		{"expired", expired.Issue("alice")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if playerId, err := signer.Verify(tt.token); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Verify = %q, %v, want ErrInvalidToken", playerId, err)
+			}
+		})
+	}
+}
@@ -1,15 +1,15 @@
 package manager
 
 import (
-	"encoding/binary"
-	"log"
+	"sync"
 
 	pb "testServer/Messages"
-
-	"google.golang.org/protobuf/proto"
 )
 
-var chatManager *ChatManager
+var (
+	chatManager     *ChatManager
+	chatManagerOnce sync.Once
+)
 
 // PlayerManager manages a list of playersH
 type ChatManager struct {
@@ -19,35 +19,40 @@ type ChatManager struct {
 
 // NewPlayerManager creates a new PlayerManager
 func GetChatManager() *ChatManager {
-	if chatManager == nil {
+	chatManagerOnce.Do(func() {
 		chatManager = &ChatManager{
 			players: make(map[int]Player),
 			nextID:  1,
 		}
-	}
+	})
 	return chatManager
 }
 
+// RegisterHandlers registers the chat message handlers
+func (pm *ChatManager) RegisterHandlers(d *Dispatcher) {
+	d.Handle((*pb.GameMessage_Chat)(nil), pm.handleChat, RequireLogin())
+}
+
+func (pm *ChatManager) handleChat(session *Session, message *pb.GameMessage) {
+	pm.Broadcast(session.PlayerId(), message.GetChat().Content)
+}
+
 // AddPlayer adds a new player to the manager
 func (pm *ChatManager) Broadcast(name string, content string) {
-	for _, player := range GetPlayerManager().ListPlayers() {
-		gameMessage := &pb.GameMessage{
-			Message: &pb.GameMessage_Chat{
-				Chat: &pb.ChatMessage{
-					Sender:  name,
-					Content: content,
-				},
+	gameMessage := &pb.GameMessage{
+		Message: &pb.GameMessage_Chat{
+			Chat: &pb.ChatMessage{
+				Sender:  name,
+				Content: content,
 			},
-		}
-		response, err := proto.Marshal(gameMessage)
-		if err != nil {
-			log.Printf("Failed to marshal response: %v", err)
-			continue
-		}
+		},
+	}
+	response := GetNetManager().MakePacket(gameMessage)
+	if response == nil {
+		return
+	}
 
-		lengthBuf := make([]byte, 4)
-		binary.LittleEndian.PutUint32(lengthBuf, uint32(len(response)))
-		lengthBuf = append(lengthBuf, response...)
-		(*player.Conn).Write(lengthBuf)
+	for _, player := range GetPlayerManager().ListPlayers() {
+		player.SendPacket(response)
 	}
 }
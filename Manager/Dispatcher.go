@@ -0,0 +1,127 @@
+package manager
+
+import (
+	"log"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"testServer/config"
+
+	pb "testServer/Messages"
+)
+
+// HandlerFunc handles one GameMessage received on a session
+type HandlerFunc func(session *Session, message *pb.GameMessage)
+
+// Middleware wraps a HandlerFunc with extra behavior
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Dispatcher routes GameMessages to handlers by their oneof message type
+type Dispatcher struct {
+	mu sync.RWMutex
+	// 등록된 그대로의 핸들러. 전역 미들웨어가 늘어나면 여기서 체인을 다시 만든다.
+	routes map[reflect.Type]route
+	// 미들웨어까지 감싼 핸들러. Dispatch는 이것만 호출한다.
+	handlers    map[reflect.Type]HandlerFunc
+	middlewares []Middleware
+	unknown     atomic.Uint64
+}
+
+// route is a handler with the middlewares it was registered with
+type route struct {
+	handler     HandlerFunc
+	middlewares []Middleware
+}
+
+var (
+	dispatcher     *Dispatcher
+	dispatcherOnce sync.Once
+)
+
+// GetDispatcher returns the server dispatcher with every manager's handlers registered
+func GetDispatcher() *Dispatcher {
+	dispatcherOnce.Do(func() {
+		dispatcher = newGameDispatcher(config.Default().Server)
+	})
+
+	return dispatcher
+}
+
+func newGameDispatcher(cfg config.ServerConfig) *Dispatcher {
+	d := NewDispatcher()
+	d.Use(Recover(), RateLimit(cfg.RateLimit, cfg.RateBurst))
+
+	GetNetManager().RegisterHandlers(d)
+	GetPlayerManager().RegisterHandlers(d)
+	GetChatManager().RegisterHandlers(d)
+	GetMonsterManager().RegisterHandlers(d)
+	GetSnapshotManager().RegisterHandlers(d)
+	return d
+}
+
+// NewDispatcher creates an empty Dispatcher
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		routes:   make(map[reflect.Type]route),
+		handlers: make(map[reflect.Type]HandlerFunc),
+	}
+}
+
+// Use adds middleware that wraps every handler, including ones already registered.
+// The first middleware is the outermost.
+func (d *Dispatcher) Use(middlewares ...Middleware) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.middlewares = append(d.middlewares, middlewares...)
+	for t, r := range d.routes {
+		d.handlers[t] = d.compose(r)
+	}
+}
+
+// Handle registers handler for a oneof case, e.g. (*pb.GameMessage_Chat)(nil).
+// Middlewares given here only wrap this handler, inside the global ones.
+func (d *Dispatcher) Handle(kind any, handler HandlerFunc, middlewares ...Middleware) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	t := reflect.TypeOf(kind)
+	if _, exists := d.routes[t]; exists {
+		log.Printf("Handler for %v registered twice, replacing", t)
+	}
+	r := route{handler: handler, middlewares: middlewares}
+	d.routes[t] = r
+	d.handlers[t] = d.compose(r)
+}
+
+// Dispatch runs the handler registered for message. Unknown messages are logged and counted.
+func (d *Dispatcher) Dispatch(session *Session, message *pb.GameMessage) {
+	d.mu.RLock()
+	handler, exists := d.handlers[reflect.TypeOf(message.Message)]
+	d.mu.RUnlock()
+
+	if !exists {
+		d.unknown.Add(1)
+		log.Printf("Session %d sent unhandled message %T", session.ID(), message.Message)
+		return
+	}
+
+	handler(session, message)
+}
+
+// UnknownCount returns how many messages had no registered handler
+func (d *Dispatcher) UnknownCount() uint64 {
+	return d.unknown.Load()
+}
+
+// compose wraps r in its own middlewares and then the global ones. d.mu must be held.
+func (d *Dispatcher) compose(r route) HandlerFunc {
+	return chain(chain(r.handler, r.middlewares), d.middlewares)
+}
+
+func chain(handler HandlerFunc, middlewares []Middleware) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
@@ -0,0 +1,114 @@
+package manager
+
+import (
+	"slices"
+	"testing"
+
+	pb "testServer/Messages"
+)
+
+// record returns a middleware that appends name to calls before running the handler
+func record(calls *[]string, name string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(session *Session, message *pb.GameMessage) {
+			*calls = append(*calls, name)
+			next(session, message)
+		}
+	}
+}
+
+func TestDispatchCountsUnknownMessages(t *testing.T) {
+	d := NewDispatcher()
+	handled := 0
+	d.Handle((*pb.GameMessage_Chat)(nil), func(*Session, *pb.GameMessage) { handled++ })
+	session := newTestSession(t)
+
+	d.Dispatch(session, chatMessage("hi"))
+	d.Dispatch(session, &pb.GameMessage{Message: &pb.GameMessage_Logout{Logout: &pb.LogoutMessage{}}})
+	d.Dispatch(session, &pb.GameMessage{})
+
+	if handled != 1 {
+		t.Fatalf("handled %d messages, want 1", handled)
+	}
+	if unknown := d.UnknownCount(); unknown != 2 {
+		t.Fatalf("unknown = %d, want 2", unknown)
+	}
+}
+
+func TestDispatchMiddlewareOrder(t *testing.T) {
+	d := NewDispatcher()
+	var calls []string
+	d.Use(record(&calls, "first"))
+	d.Handle((*pb.GameMessage_Chat)(nil), func(*Session, *pb.GameMessage) {
+		calls = append(calls, "handler")
+	}, record(&calls, "local"))
+	// 핸들러를 등록한 뒤에 추가한 전역 미들웨어도 적용돼야 한다
+	d.Use(record(&calls, "second"))
+
+	d.Dispatch(newTestSession(t), chatMessage("hi"))
+	if want := []string{"first", "second", "local", "handler"}; !slices.Equal(calls, want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestRequireLoginRunsInsideGlobalMiddleware(t *testing.T) {
+	d := NewDispatcher()
+	var calls []string
+	d.Use(record(&calls, "global"))
+	d.Handle((*pb.GameMessage_Chat)(nil), func(*Session, *pb.GameMessage) {
+		calls = append(calls, "handler")
+	}, RequireLogin())
+
+	session := newTestSession(t)
+	d.Dispatch(session, chatMessage("before login"))
+	if want := []string{"global"}; !slices.Equal(calls, want) {
+		t.Fatalf("before login: calls = %v, want %v", calls, want)
+	}
+
+	calls = nil
+	session.BindPlayer("alice")
+	d.Dispatch(session, chatMessage("after login"))
+	if want := []string{"global", "handler"}; !slices.Equal(calls, want) {
+		t.Fatalf("after login: calls = %v, want %v", calls, want)
+	}
+}
+
+func TestRecoverKeepsDispatching(t *testing.T) {
+	d := NewDispatcher()
+	d.Use(Recover())
+	handled := 0
+	d.Handle((*pb.GameMessage_Chat)(nil), func(*Session, *pb.GameMessage) {
+		handled++
+		panic("broken handler")
+	})
+
+	session := newTestSession(t)
+	d.Dispatch(session, chatMessage("one"))
+	d.Dispatch(session, chatMessage("two"))
+	if handled != 2 {
+		t.Fatalf("handled %d messages, want 2", handled)
+	}
+}
+
+func TestRateLimitPerSession(t *testing.T) {
+	d := NewDispatcher()
+	// 테스트 동안 토큰이 다시 차지 않도록 속도를 아주 낮게 둔다
+	d.Use(RateLimit(0.001, 3))
+	handled := make(map[*Session]int)
+	d.Handle((*pb.GameMessage_Chat)(nil), func(session *Session, _ *pb.GameMessage) {
+		handled[session]++
+	})
+
+	flooder, quiet := newTestSession(t), newTestSession(t)
+	for i := 0; i < 10; i++ {
+		d.Dispatch(flooder, chatMessage("spam"))
+	}
+	d.Dispatch(quiet, chatMessage("hello"))
+
+	if handled[flooder] != 3 {
+		t.Fatalf("flooder got %d messages through, want the burst of 3", handled[flooder])
+	}
+	if handled[quiet] != 1 {
+		t.Fatalf("quiet session got %d messages through, want 1", handled[quiet])
+	}
+}
@@ -0,0 +1,66 @@
+package manager
+
+import (
+	"time"
+
+	"testServer/behavior"
+	"testServer/config"
+)
+
+// Init creates every manager from cfg. It must be called before any GetXxxManager;
+// a manager that was already created keeps the settings it was created with.
+func Init(cfg *config.Config) error {
+	authenticator, err := NewFileAuthenticator(cfg.Auth.UsersFile)
+	if err != nil {
+		return err
+	}
+	tokens, err := NewTokenSigner(cfg.Auth.TokenSecret, time.Duration(cfg.Auth.TokenTTL))
+	if err != nil {
+		return err
+	}
+	// 몬스터 템플릿이 트리 이름을 참조하므로 트리 정의를 먼저 읽는다
+	if cfg.Monster.TreesFile != "" {
+		trees, err := loadBehaviorTrees(cfg.Monster.TreesFile)
+		if err != nil {
+			return err
+		}
+		behavior.UseTreeFile(trees)
+	}
+	monsters, err := LoadMonsterData(cfg.Monster.DataFile)
+	if err != nil {
+		return err
+	}
+
+	netManagerOnce.Do(func() {
+		netManager = newNetManager(cfg.Server)
+	})
+	var navMeshErr error
+	navMeshManagerOnce.Do(func() {
+		navMeshManager, navMeshErr = newNavMeshManager(cfg.NavMesh)
+	})
+	if navMeshErr != nil {
+		return navMeshErr
+	}
+	if err := monsters.Validate(navMeshManager); err != nil {
+		return err
+	}
+	playerManagerOnce.Do(func() {
+		playerManager = newPlayerManager(cfg, authenticator, tokens)
+	})
+	aoiManagerOnce.Do(func() {
+		aoiManager = NewAOIManager(cfg.World.ViewRadius)
+	})
+	monsterManagerOnce.Do(func() {
+		monsterManager = newMonsterManager(cfg.Combat, cfg.Monster.TreesFile)
+	})
+	if err := monsterManager.Populate(monsters); err != nil {
+		return err
+	}
+	worldOnce.Do(func() {
+		world = NewWorld(cfg.World.TickRate)
+	})
+	dispatcherOnce.Do(func() {
+		dispatcher = newGameDispatcher(cfg.Server)
+	})
+	return nil
+}
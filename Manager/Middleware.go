@@ -0,0 +1,89 @@
+package manager
+
+import (
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	pb "testServer/Messages"
+)
+
+// Recover keeps a panicking handler from taking down the server
+func Recover() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(session *Session, message *pb.GameMessage) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Handler for %T panicked on session %d: %v\n%s", message.Message, session.ID(), r, debug.Stack())
+				}
+			}()
+			next(session, message)
+		}
+	}
+}
+
+// RequireLogin drops messages from sessions that have not logged in yet
+func RequireLogin() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(session *Session, message *pb.GameMessage) {
+			if session.PlayerId() == "" {
+				log.Printf("Session %d sent %T before login, ignoring", session.ID(), message.Message)
+				return
+			}
+			next(session, message)
+		}
+	}
+}
+
+// RateLimit drops messages once a session exceeds perSecond messages, allowing bursts up to burst
+func RateLimit(perSecond float64, burst int) Middleware {
+	var mu sync.Mutex
+	buckets := make(map[uint64]*tokenBucket)
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(session *Session, message *pb.GameMessage) {
+			mu.Lock()
+			bucket, exists := buckets[session.ID()]
+			if !exists {
+				bucket = &tokenBucket{tokens: float64(burst), last: time.Now()}
+				buckets[session.ID()] = bucket
+
+				// 세션이 닫히면 버킷을 정리한다
+				go func() {
+					<-session.Closed()
+					mu.Lock()
+					delete(buckets, session.ID())
+					mu.Unlock()
+				}()
+			}
+			allowed := bucket.take(perSecond, float64(burst), time.Now())
+			mu.Unlock()
+
+			if !allowed {
+				log.Printf("Session %d rate limited, dropping %T", session.ID(), message.Message)
+				return
+			}
+			next(session, message)
+		}
+	}
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) take(rate float64, burst float64, now time.Time) bool {
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,183 @@
+package manager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"testServer/behavior"
+	"testServer/config"
+	"testServer/vecmath"
+)
+
+// MonsterTemplate is the stats and behavior shared by every monster of one kind.
+// Speeds are in units per second. Patrol is a loop of navmesh points walked at
+// PatrolSpeed (Speed when unset).
+// A chasing monster re-paths once its target moves RepathDistance away from the
+// point it last found a path to.
+type MonsterTemplate struct {
+	Name           string          `json:"name"`
+	MaxHP          int             `json:"max_hp"`
+	Speed          float32         `json:"speed"`
+	PatrolSpeed    float32         `json:"patrol_speed"`
+	RepathDistance float32         `json:"repath_distance"`
+	DetectRange    float32         `json:"detect_range"`
+	AttackRange    float32         `json:"attack_range"`
+	AttackDamage   int             `json:"attack_damage"`
+	AttackCooldown config.Duration `json:"attack_cooldown"`
+	Tree           string          `json:"tree"`
+	Patrol         []PatrolPoint   `json:"patrol"`
+}
+
+type PatrolPoint struct {
+	X float32 `json:"x"`
+	Z float32 `json:"z"`
+}
+
+// Vec3 returns the point on the ground plane
+func (p PatrolPoint) Vec3() vecmath.Vec3 {
+	return vecmath.New(p.X, 0, p.Z)
+}
+
+// SpawnRegion keeps up to MaxCount monsters of Template alive within Radius of (X, Z).
+// A dead monster comes back at a new point in the region after RespawnTime.
+type SpawnRegion struct {
+	Name        string          `json:"name"`
+	Template    string          `json:"template"`
+	X           float32         `json:"x"`
+	Z           float32         `json:"z"`
+	Radius      float32         `json:"radius"`
+	MaxCount    int             `json:"max_count"`
+	RespawnTime config.Duration `json:"respawn_time"`
+}
+
+// MonsterData is the monster data file loaded at startup
+type MonsterData struct {
+	Templates    []*MonsterTemplate `json:"templates"`
+	SpawnRegions []*SpawnRegion     `json:"spawn_regions"`
+}
+
+// DefaultMonsterTemplate is used by monsters added without a template
+var DefaultMonsterTemplate = MonsterTemplate{
+	Name:           "default",
+	MaxHP:          100,
+	Speed:          3.0,
+	PatrolSpeed:    2.0,
+	DetectRange:    10.0,
+	AttackRange:    2.0,
+	AttackDamage:   10,
+	AttackCooldown: config.Duration(time.Second),
+	Tree:           "monster1",
+}
+
+// LoadMonsterData reads the monster templates and spawn regions at path
+func LoadMonsterData(path string) (*MonsterData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open monster data: %w", err)
+	}
+	defer file.Close()
+
+	var data MonsterData
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("decode monster data %s: %w", path, err)
+	}
+	return &data, nil
+}
+
+// loadBehaviorTrees reads and validates the behavior tree definitions at path
+func loadBehaviorTrees(path string) (*behavior.TreeFile, error) {
+	trees, err := behavior.LoadTreeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := trees.Validate(); err != nil {
+		return nil, fmt.Errorf("behavior trees %s: %w", path, err)
+	}
+	return trees, nil
+}
+
+// Template returns the template called name
+func (md *MonsterData) Template(name string) (*MonsterTemplate, bool) {
+	for _, template := range md.Templates {
+		if template.Name == name {
+			return template, true
+		}
+	}
+	return nil, false
+}
+
+// Validate reports every invalid template and spawn region at once. Patrol points
+// and spawn region centers must lie on navMesh.
+func (md *MonsterData) Validate(navMesh *NavMeshManager) error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	names := make(map[string]bool, len(md.Templates))
+	for i, t := range md.Templates {
+		check(t.Name != "", "templates[%d]: name must be set", i)
+		check(!names[t.Name], "templates[%d]: duplicate name %q", i, t.Name)
+		names[t.Name] = true
+		check(t.MaxHP > 0, "template %q: max_hp must be positive", t.Name)
+		check(t.Speed > 0, "template %q: speed must be positive", t.Name)
+		check(t.PatrolSpeed >= 0, "template %q: patrol_speed must not be negative", t.Name)
+		check(t.RepathDistance >= 0, "template %q: repath_distance must not be negative", t.Name)
+		check(t.DetectRange > 0, "template %q: detect_range must be positive", t.Name)
+		check(t.AttackRange > 0, "template %q: attack_range must be positive", t.Name)
+		check(t.AttackRange <= t.DetectRange, "template %q: attack_range must not exceed detect_range", t.Name)
+		check(t.AttackDamage >= 0, "template %q: attack_damage must not be negative", t.Name)
+		check(t.AttackCooldown >= 0, "template %q: attack_cooldown must not be negative", t.Name)
+		// "$stat" params의 값은 템플릿마다 다르므로 템플릿의 수치로 트리를 만들어 본다
+		if err := behavior.CheckTree(t.Tree, t.params()); err != nil {
+			errs = append(errs, fmt.Errorf("template %q: %w", t.Name, err))
+		}
+		for j, point := range t.Patrol {
+			check(navMesh.Contains(point.Vec3()),
+				"template %q: patrol[%d] (%v, %v) is off the navmesh", t.Name, j, point.X, point.Z)
+		}
+	}
+
+	for i, r := range md.SpawnRegions {
+		check(names[r.Template], "spawn_regions[%d] %q: unknown template %q", i, r.Name, r.Template)
+		check(r.Radius >= 0, "spawn_regions[%d] %q: radius must not be negative", i, r.Name)
+		check(r.MaxCount > 0, "spawn_regions[%d] %q: max_count must be positive", i, r.Name)
+		check(r.RespawnTime > 0, "spawn_regions[%d] %q: respawn_time must be positive", i, r.Name)
+		check(navMesh.Contains(vecmath.New(r.X, 0, r.Z)),
+			"spawn_regions[%d] %q: center (%v, %v) is off the navmesh", i, r.Name, r.X, r.Z)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *MonsterTemplate) params() behavior.MonsterParams {
+	patrolSpeed := t.PatrolSpeed
+	if patrolSpeed == 0 {
+		patrolSpeed = t.Speed
+	}
+	return behavior.MonsterParams{
+		DetectRange:    t.DetectRange,
+		AttackRange:    t.AttackRange,
+		AttackDamage:   t.AttackDamage,
+		AttackCooldown: time.Duration(t.AttackCooldown),
+		ChaseSpeed:     t.Speed,
+		PatrolSpeed:    patrolSpeed,
+		RepathDistance: t.RepathDistance,
+	}
+}
+
+// patrolPath converts the template's patrol route to game-space points
+func (t *MonsterTemplate) patrolPath() []vecmath.Vec3 {
+	path := make([]vecmath.Vec3, 0, len(t.Patrol))
+	for _, point := range t.Patrol {
+		path = append(path, point.Vec3())
+	}
+	return path
+}
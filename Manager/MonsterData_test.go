@@ -0,0 +1,142 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"testServer/config"
+)
+
+func TestExampleMonsterDataIsValid(t *testing.T) {
+	data, err := LoadMonsterData("../monsters.example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := data.Validate(newTestNavMesh(1000)); err != nil {
+		t.Fatalf("example monster data is invalid: %v", err)
+	}
+}
+
+func TestLoadMonsterDataRejectsUnknownFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		json  string
+		field string
+	}{
+		{"top level", `{"templates": [], "spawn_region": []}`, "spawn_region"},
+		{"template", `{"templates": [{"name": "slime", "max_health": 10}]}`, "max_health"},
+		{"spawn region", `{"spawn_regions": [{"name": "field", "respawn": "10s"}]}`, "respawn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "monsters.json")
+			if err := os.WriteFile(path, []byte(tt.json), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadMonsterData(path)
+			if err == nil || !strings.Contains(err.Error(), tt.field) {
+				t.Fatalf("LoadMonsterData = %v, want unknown field %q rejected", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestMonsterDataValidate(t *testing.T) {
+	// 테스트 네비메시는 높이 0의 ±100 정사각형이다
+	navMesh := newTestNavMesh(100)
+	valid := func() *MonsterData {
+		template := DefaultMonsterTemplate
+		template.Name = "slime"
+		template.Patrol = []PatrolPoint{{X: 5, Z: 5}, {X: -5, Z: 5}}
+		return &MonsterData{
+			Templates: []*MonsterTemplate{&template},
+			SpawnRegions: []*SpawnRegion{{
+				Name:        "field",
+				Template:    "slime",
+				X:           10,
+				Z:           10,
+				Radius:      5,
+				MaxCount:    3,
+				RespawnTime: config.Duration(10 * time.Second),
+			}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(data *MonsterData)
+		wantErr []string
+	}{
+		{"valid", func(*MonsterData) {}, nil},
+		{
+			"unknown template",
+			func(data *MonsterData) { data.SpawnRegions[0].Template = "dragon" },
+			[]string{`unknown template "dragon"`},
+		},
+		{
+			"zero max hp",
+			func(data *MonsterData) { data.Templates[0].MaxHP = 0 },
+			[]string{"max_hp must be positive"},
+		},
+		{
+			"negative max hp",
+			func(data *MonsterData) { data.Templates[0].MaxHP = -10 },
+			[]string{"max_hp must be positive"},
+		},
+		{
+			"zero respawn time",
+			func(data *MonsterData) { data.SpawnRegions[0].RespawnTime = 0 },
+			[]string{"respawn_time must be positive"},
+		},
+		{
+			"negative respawn time",
+			func(data *MonsterData) { data.SpawnRegions[0].RespawnTime = config.Duration(-time.Second) },
+			[]string{"respawn_time must be positive"},
+		},
+		{
+			"spawn center off the navmesh",
+			func(data *MonsterData) { data.SpawnRegions[0].X = 500 },
+			[]string{"center (500, 10) is off the navmesh"},
+		},
+		{
+			"patrol point off the navmesh",
+			func(data *MonsterData) { data.Templates[0].Patrol[1].Z = -200 },
+			[]string{"patrol[1] (-5, -200) is off the navmesh"},
+		},
+		{
+			"every error is reported",
+			func(data *MonsterData) {
+				data.Templates[0].MaxHP = 0
+				data.SpawnRegions[0].Template = "dragon"
+				data.SpawnRegions[0].RespawnTime = 0
+			},
+			[]string{"max_hp must be positive", `unknown template "dragon"`, "respawn_time must be positive"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := valid()
+			tt.mutate(data)
+			err := data.Validate(navMesh)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate = nil, want %q", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate = %v, missing %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -1,59 +1,454 @@
 package manager
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
 	"testServer/behavior"
+	"testServer/config"
+	"testServer/vecmath"
 
 	pb "testServer/Messages"
 )
 
-var monsterManager *MonsterManager
+var (
+	monsterManager     *MonsterManager
+	monsterManagerOnce sync.Once
+)
+
+var ErrMonsterNotFound = errors.New("monster not found")
+
+// 스폰 영역 안에서 네비메시 위의 지점을 찾기 위해 시도하는 횟수. 모두 실패하면 영역 중심에 스폰한다.
+const spawnPointAttempts = 10
+
+// MonsterDefinition describes what a monster is, where it spawns and how it comes back after dying.
+// Each (re)spawn picks a point within SpawnRadius of (SpawnX, SpawnZ).
+type MonsterDefinition struct {
+	Template       *MonsterTemplate
+	SpawnX, SpawnZ float32
+	SpawnRadius    float32
+	RespawnDelay   time.Duration
+}
+
+// MonsterStatus is what clients see of a living monster. TargetId is set while it chases or attacks.
+type MonsterStatus struct {
+	Pos      vecmath.Vec3
+	HP       int
+	Action   behavior.Action
+	TargetId string
+}
+
+// MonsterDebugInfo is a living monster's status with the contents of its tree's blackboard
+type MonsterDebugInfo struct {
+	MonsterId  int32             `json:"monster_id"`
+	Template   string            `json:"template"`
+	Tree       string            `json:"tree"`
+	X          float32           `json:"x"`
+	Y          float32           `json:"y"`
+	Z          float32           `json:"z"`
+	HP         int               `json:"hp"`
+	Action     string            `json:"action"`
+	Blackboard map[string]string `json:"blackboard"`
+}
+
+// monsterEntry is the lifecycle state kept per MonsterId
+type monsterEntry struct {
+	monster    *behavior.Monster
+	tree       *behavior.Tree
+	definition MonsterDefinition
+	dead       bool
+	// 죽은 몬스터가 다시 나타나기까지 남은 시간 (월드 틱마다 줄어든다)
+	respawnIn time.Duration
+	// 추적 중일 때만 설정된다. 다시 스폰해도 새 트리에 이어 붙인다.
+	tracer *behavior.Tracer
+}
 
-// PlayerManager manages a list of players
+// MonsterManager owns every monster and runs their spawn, death and respawn cycle
 type MonsterManager struct {
-	monsters map[string]*behavior.Monster
-	nextID   int32
+	mu        sync.RWMutex
+	monsters  map[int32]*monsterEntry
+	nextID    int32
+	combat    config.CombatConfig
+	treesFile string
+	// mm.mu를 잡은 동안 쌓인 메시지. 락을 놓은 뒤 unlock이 보낸다.
+	outbox []observerMessage
+	// 행동 트리가 보는 시뮬레이션 시각. 월드 틱마다 dt만큼 흐른다.
+	now time.Time
 }
 
-// NewPlayerManager creates a new PlayerManager
+// GetMonsterManager returns the MonsterManager
 func GetMonsterManager() *MonsterManager {
-	if monsterManager == nil {
-		monsterManager = &MonsterManager{
-			monsters: make(map[string]*behavior.Monster),
-			nextID:   1,
+	monsterManagerOnce.Do(func() {
+		cfg := config.Default()
+		monsterManager = newMonsterManager(cfg.Combat, cfg.Monster.TreesFile)
+	})
+
+	return monsterManager
+}
+
+func newMonsterManager(combat config.CombatConfig, treesFile string) *MonsterManager {
+	return &MonsterManager{
+		monsters:  make(map[int32]*monsterEntry),
+		nextID:    1,
+		combat:    combat,
+		treesFile: treesFile,
+		now:       time.Now(),
+	}
+}
+
+// Populate fills every spawn region of data up to its max count. data must be validated first.
+func (mm *MonsterManager) Populate(data *MonsterData) error {
+	for _, region := range data.SpawnRegions {
+		template, exists := data.Template(region.Template)
+		if !exists {
+			return fmt.Errorf("spawn region %q: unknown template %q", region.Name, region.Template)
+		}
+		for i := 0; i < region.MaxCount; i++ {
+			_, err := mm.AddMonster(MonsterDefinition{
+				Template:     template,
+				SpawnX:       region.X,
+				SpawnZ:       region.Z,
+				SpawnRadius:  region.Radius,
+				RespawnDelay: time.Duration(region.RespawnTime),
+			})
+			if err != nil {
+				return fmt.Errorf("spawn region %q: %w", region.Name, err)
+			}
 		}
 	}
+	return nil
+}
 
-	return monsterManager
+// RegisterHandlers registers the monster message handlers
+func (mm *MonsterManager) RegisterHandlers(d *Dispatcher) {
+	d.Handle((*pb.GameMessage_AttackMonster)(nil), mm.handleAttackMonster, RequireLogin())
+}
+
+func (mm *MonsterManager) handleAttackMonster(session *Session, message *pb.GameMessage) {
+	player, err := GetPlayerManager().GetPlayer(session.PlayerId())
+	if err != nil {
+		return
+	}
+	if !player.tryAttack(time.Now(), time.Duration(mm.combat.AttackCooldown)) {
+		return
+	}
+
+	// 피해량과 사거리는 클라이언트가 아니라 서버 설정을 따른다
+	position := player.Position()
+	mm.mu.Lock()
+	entry, exists := mm.monsters[message.GetAttackMonster().MonsterId]
+	if !exists || entry.dead || position.DistanceXZ(entry.monster.Pos) > mm.combat.AttackRange {
+		mm.mu.Unlock()
+		return
+	}
+	events := mm.damage(entry, mm.combat.AttackDamage, player.Name)
+	mm.unlock()
+
+	DeliverAOIEvents(events)
 }
 
-// AddPlayer adds a new player to the manager
-func (mm *MonsterManager) AddMonster(id int32) *behavior.Monster {
-	monster := behavior.Monster{
-		MonsterId: mm.nextID,
-		X:         0,
-		Z:         0,
+// AddMonster spawns a new monster from definition and shows it to the players around its spawn point.
+// A definition without a template uses DefaultMonsterTemplate.
+func (mm *MonsterManager) AddMonster(definition MonsterDefinition) (*behavior.Monster, error) {
+	if definition.Template == nil {
+		definition.Template = &DefaultMonsterTemplate
 	}
 
-	mm.monsters[id] = &monster
+	mm.mu.Lock()
+	entry := &monsterEntry{
+		monster:    &behavior.Monster{MonsterId: mm.nextID},
+		definition: definition,
+	}
+	events, err := mm.spawn(entry)
+	if err != nil {
+		mm.mu.Unlock()
+		return nil, fmt.Errorf("template %q: %w", definition.Template.Name, err)
+	}
+	mm.monsters[entry.monster.MonsterId] = entry
 	mm.nextID++
+	mm.mu.Unlock()
+
+	// 시야 안에 있는 플레이어들에게만 스폰시켜달라고 한다.
+	DeliverAOIEvents(events)
+
+	return entry.monster, nil
+}
+
+// ReloadTrees reads the behavior tree file again and rebuilds the tree of every living
+// monster, keeping its blackboard. Nothing changes if the file is invalid or a monster
+// template's tree can't be built with the template's stats.
+func (mm *MonsterManager) ReloadTrees() error {
+	if mm.treesFile == "" {
+		return errors.New("no behavior trees file configured")
+	}
+	trees, err := loadBehaviorTrees(mm.treesFile)
+	if err != nil {
+		return err
+	}
+
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	previous := behavior.UseTreeFile(trees)
+	checked := make(map[*MonsterTemplate]bool)
+	for _, entry := range mm.monsters {
+		template := entry.definition.Template
+		if checked[template] {
+			continue
+		}
+		checked[template] = true
+		if err := behavior.CheckTree(template.Tree, template.params()); err != nil {
+			behavior.UseTreeFile(previous)
+			return fmt.Errorf("template %q: %w", template.Name, err)
+		}
+	}
+
+	// 죽어 있는 몬스터는 다시 스폰될 때 새 정의로 트리를 만든다
+	for _, entry := range mm.monsters {
+		if entry.dead {
+			continue
+		}
+		if err := entry.tree.Reload(); err != nil {
+			log.Printf("Monster %d keeps its old behavior tree: %v", entry.monster.MonsterId, err)
+		}
+	}
+	return nil
+}
+
+// MonsterStatus returns the current status of a living monster
+func (mm *MonsterManager) MonsterStatus(id int32) (MonsterStatus, bool) {
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+	entry, exists := mm.monsters[id]
+	if !exists || entry.dead {
+		return MonsterStatus{}, false
+	}
 
-	// 내가 로그인 되었음을 나한테 알려준다.
-	MonsterSapwn := &pb.GameMessage{
-		Message: &pb.GameMessage_SpawnMonster{
-			SpawnMonster: &pb.SpawnMonster{
-				X:         monster.X,
-				Z:         monster.Z,
-				MonsterId: monster.MonsterId,
+	monster := entry.monster
+	status := MonsterStatus{Pos: monster.Pos, HP: monster.HP, Action: monster.Action}
+	if monster.Action == behavior.ActionChase || monster.Action == behavior.ActionAttack {
+		target, _ := behavior.Get(entry.tree.Blackboard, behavior.KeyTarget)
+		status.TargetId = targetId(target)
+	}
+	return status, true
+}
+
+// DebugInfo returns every living monster with its blackboard, ordered by MonsterId
+func (mm *MonsterManager) DebugInfo() []MonsterDebugInfo {
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+	infos := make([]MonsterDebugInfo, 0, len(mm.monsters))
+	for id, entry := range mm.monsters {
+		if entry.dead {
+			continue
+		}
+		monster := entry.monster
+		infos = append(infos, MonsterDebugInfo{
+			MonsterId:  id,
+			Template:   entry.definition.Template.Name,
+			Tree:       entry.tree.Name,
+			X:          monster.Pos.X,
+			Y:          monster.Pos.Y,
+			Z:          monster.Pos.Z,
+			HP:         monster.HP,
+			Action:     monster.Action.String(),
+			Blackboard: entry.tree.Blackboard.Inspect(),
+		})
+	}
+	slices.SortFunc(infos, func(a, b MonsterDebugInfo) int {
+		return cmp.Compare(a.MonsterId, b.MonsterId)
+	})
+	return infos
+}
+
+// TraceMonster starts recording which behavior tree nodes monster id runs over its last
+// ticks ticks, replacing any earlier trace. Zero ticks stops tracing.
+func (mm *MonsterManager) TraceMonster(id int32, ticks int) error {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	entry, exists := mm.monsters[id]
+	if !exists {
+		return ErrMonsterNotFound
+	}
+	entry.tracer = nil
+	if ticks > 0 {
+		entry.tracer = behavior.NewTracer(ticks)
+	}
+	entry.tree.Tracer = entry.tracer
+	return nil
+}
+
+// MonsterTrace returns the ticks recorded for monster id, oldest first, or nil when it isn't traced
+func (mm *MonsterManager) MonsterTrace(id int32) ([]behavior.TickTrace, error) {
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+
+	entry, exists := mm.monsters[id]
+	if !exists {
+		return nil, ErrMonsterNotFound
+	}
+	if entry.tracer == nil {
+		return nil, nil
+	}
+	return entry.tracer.Ticks(), nil
+}
+
+// Update runs every living monster's behavior tree once and counts down respawn timers.
+// Called from the world loop.
+func (mm *MonsterManager) Update(dt time.Duration) {
+	mm.mu.Lock()
+	var events []AOIEvent
+	mm.now = mm.now.Add(dt)
+	world := monsterWorld{players: GetPlayerManager().ListPlayers()}
+	for id, entry := range mm.monsters {
+		if entry.dead {
+			entry.respawnIn -= dt
+			if entry.respawnIn <= 0 {
+				spawnEvents, err := mm.spawn(entry)
+				if err != nil {
+					log.Printf("Monster %d failed to respawn, retrying in %v: %v", id, entry.definition.RespawnDelay, err)
+					entry.respawnIn = entry.definition.RespawnDelay
+				}
+				events = append(events, spawnEvents...)
+			}
+			continue
+		}
+
+		monster := entry.monster
+		monster.Action = behavior.ActionIdle
+		entry.tree.Tick(world, mm.now, dt)
+		events = append(events, GetAOIManager().Move(MonsterRef(id), monster.Pos.X, monster.Pos.Z)...)
+	}
+	mm.unlock()
+
+	DeliverAOIEvents(events)
+}
+
+// unlock releases mm.mu and then sends the messages queued while it was held, so a
+// session that blocks on a full queue never stalls the monster manager
+func (mm *MonsterManager) unlock() {
+	outbox := mm.outbox
+	mm.outbox = nil
+	mm.mu.Unlock()
+	deliverObserverMessages(outbox)
+}
+
+// spawn (re)places entry in its spawn area with full HP and a fresh behavior tree
+// and blackboard. entry is left as it was if its tree can't be built. mm.mu must be held.
+func (mm *MonsterManager) spawn(entry *monsterEntry) ([]AOIEvent, error) {
+	template := entry.definition.Template
+	monster := entry.monster
+	tree, err := behavior.NewTree(template.Tree, monster, template.params())
+	if err != nil {
+		return nil, err
+	}
+
+	entry.tree = tree
+	entry.tree.Tracer = entry.tracer
+	monster.Pos = spawnPoint(entry.definition)
+	monster.HP = template.MaxHP
+	monster.Action = behavior.ActionIdle
+	monster.OnAttack = func(target behavior.Target, damage int) {
+		mm.outbox = append(mm.outbox, messageForObservers(MonsterRef(monster.MonsterId), &pb.GameMessage{
+			Message: &pb.GameMessage_MonsterAttack{
+				MonsterAttack: &pb.MonsterAttack{
+					MonsterId: monster.MonsterId,
+					TargetId:  targetId(target),
+					Damage:    int32(damage),
+				},
 			},
+		}))
+	}
+	behavior.Set(entry.tree.Blackboard, behavior.KeyHome, monster.Pos)
+	behavior.Set(entry.tree.Blackboard, behavior.KeyPatrolPath, template.patrolPath())
+	entry.dead = false
+	entry.respawnIn = 0
+
+	return GetAOIManager().Add(MonsterRef(monster.MonsterId), monster.Pos.X, monster.Pos.Z), nil
+}
+
+// damage lowers entry's HP and kills it at zero, crediting killerId. mm.mu must be held.
+func (mm *MonsterManager) damage(entry *monsterEntry, damage int, killerId string) []AOIEvent {
+	monster := entry.monster
+	monster.HP -= damage
+	if monster.HP > 0 {
+		return nil
+	}
+
+	// 죽은 몬스터는 시야에서 지우고 respawn_time 뒤에 스폰 영역에서 다시 살린다
+	log.Printf("Monster %d died, respawning in %v", monster.MonsterId, entry.definition.RespawnDelay)
+	mm.outbox = append(mm.outbox, messageForObservers(MonsterRef(monster.MonsterId), &pb.GameMessage{
+		Message: &pb.GameMessage_MonsterDied{
+			MonsterDied: &pb.MonsterDied{MonsterId: monster.MonsterId, KillerId: killerId},
 		},
+	}))
+	monster.HP = 0
+	entry.tree.Abort()
+	entry.tree.Blackboard.Clear()
+	monster.Action = behavior.ActionIdle
+	entry.dead = true
+	entry.respawnIn = entry.definition.RespawnDelay
+	return GetAOIManager().Remove(MonsterRef(monster.MonsterId))
+}
+
+// monsterWorld is what behavior trees can query during one world tick
+type monsterWorld struct {
+	players []*Player
+}
+
+func (w monsterWorld) FindPath(from, to vecmath.Vec3) ([]vecmath.Vec3, error) {
+	return GetNavMeshManager().FindPath(from, to)
+}
+
+// NearestTarget returns the player closest to from on the X/Z plane, or nil
+func (w monsterWorld) NearestTarget(from vecmath.Vec3) behavior.Target {
+	var nearest *Player
+	best := float32(math.MaxFloat32)
+	for _, p := range w.players {
+		if dist := from.DistanceXZ(p.Position()); dist < best {
+			best = dist
+			nearest = p
+		}
 	}
 
-	// 이 코드를 들어온 유저를 제외한 플레이어들에게 스폰시켜달라고 한다.
-	for _, p := range GetPlayerManager().players {
+	// nil *Player를 인터페이스에 담으면 nil 비교가 깨지므로 명시적으로 nil을 돌려준다
+	if nearest == nil {
+		return nil
+	}
+	return nearest
+}
 
-		response := GetNetManager().MakePacket(MonsterSapwn)
-		(*p.Conn).Write(response)
+// spawnPoint picks a random point on the navmesh within the definition's spawn radius
+func spawnPoint(definition MonsterDefinition) vecmath.Vec3 {
+	center := vecmath.New(definition.SpawnX, 0, definition.SpawnZ)
+	if definition.SpawnRadius <= 0 {
+		return center
 	}
 
-	return &monster
+	navMesh := GetNavMeshManager()
+	for i := 0; i < spawnPointAttempts; i++ {
+		// 원 안에 고르게 퍼지도록 반지름에 제곱근을 쓴다
+		angle := rand.Float64() * 2 * math.Pi
+		radius := float64(definition.SpawnRadius) * math.Sqrt(rand.Float64())
+		point := center.Add(vecmath.New(float32(radius*math.Cos(angle)), 0, float32(radius*math.Sin(angle))))
+		if navMesh.Contains(point) {
+			return point
+		}
+	}
+	return center
+}
+
+// targetId returns the player id behind a behavior target, or "" for none
+func targetId(target behavior.Target) string {
+	if player, ok := target.(*Player); ok {
+		return player.Name
+	}
+	return ""
 }
@@ -0,0 +1,140 @@
+package manager
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"testServer/behavior"
+	"testServer/config"
+	"testServer/vecmath"
+
+	pb "testServer/Messages"
+)
+
+// 트리 정의가 템플릿 수치로 만들어지지 않으면 몬스터를 추가하지 않고 오류를 돌려준다
+func TestAddMonsterRejectsTreeItCannotBuild(t *testing.T) {
+	var def behavior.NodeDefinition
+	tree := `{"type": "cooldown", "params": {"duration": "$attack_cooldown"}, "children": [{"type": "attack"}]}`
+	if err := json.Unmarshal([]byte(tree), &def); err != nil {
+		t.Fatal(err)
+	}
+	previous := behavior.UseTreeFile(&behavior.TreeFile{Trees: map[string]*behavior.NodeDefinition{"guarded": &def}})
+	t.Cleanup(func() { behavior.UseTreeFile(previous) })
+
+	mm := newMonsterManager(config.Default().Combat, "")
+	template := DefaultMonsterTemplate
+	template.Name = "sleepy"
+	template.Tree = "guarded"
+	template.AttackCooldown = 0
+
+	_, err := mm.AddMonster(MonsterDefinition{Template: &template})
+	if err == nil || !strings.Contains(err.Error(), `param "duration" must be positive`) {
+		t.Fatalf("AddMonster = %v, want the cooldown duration rejected", err)
+	}
+	if monsters := mm.DebugInfo(); len(monsters) != 0 {
+		t.Fatalf("failed spawn left %d monsters behind", len(monsters))
+	}
+
+	template.AttackCooldown = config.Duration(time.Second)
+	if _, err := mm.AddMonster(MonsterDefinition{Template: &template}); err != nil {
+		t.Fatalf("AddMonster with a cooldown: %v", err)
+	}
+}
+
+// waitForMessage reads messages until match accepts one, failing after a second
+func waitForMessage(t *testing.T, messages <-chan *pb.GameMessage, what string, match func(*pb.GameMessage) bool) {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case message := <-messages:
+			if match(message) {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("never received %s", what)
+		}
+	}
+}
+
+// seesMonster reports whether playerId's AOI view contains monster id
+func seesMonster(playerId string, id int32) bool {
+	for _, event := range GetAOIManager().Visible(playerId) {
+		if event.Entity == MonsterRef(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// 쓰러진 몬스터는 시야에서 사라지고, respawn 지연 뒤 스폰 영역 안에서 체력을 채워 다시 나타난다
+func TestMonsterDiesAndRespawnsInRegion(t *testing.T) {
+	mm := GetMonsterManager()
+	session, messages := newTestClient(t)
+	GetDispatcher().Dispatch(session, &pb.GameMessage{
+		Message: &pb.GameMessage_Login{Login: &pb.LoginMessage{PlayerId: "hunter"}},
+	})
+	defer GetPlayerManager().RemovePlayer("hunter")
+
+	template := DefaultMonsterTemplate
+	template.Name = "fragile"
+	template.MaxHP = config.Default().Combat.AttackDamage
+	center := vecmath.New(1, 0, 1)
+	monster, err := mm.AddMonster(MonsterDefinition{
+		Template:     &template,
+		SpawnX:       center.X,
+		SpawnZ:       center.Z,
+		SpawnRadius:  1,
+		RespawnDelay: 200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := monster.MonsterId
+	waitForMessage(t, messages, "the spawn", func(message *pb.GameMessage) bool {
+		return message.GetSpawnMonster().GetMonsterId() == id
+	})
+
+	// 한 번의 공격으로 체력이 0이 된다
+	GetDispatcher().Dispatch(session, &pb.GameMessage{
+		Message: &pb.GameMessage_AttackMonster{AttackMonster: &pb.AttackMonster{MonsterId: id}},
+	})
+	waitForMessage(t, messages, "the death", func(message *pb.GameMessage) bool {
+		died := message.GetMonsterDied()
+		return died.GetMonsterId() == id && died.GetKillerId() == "hunter"
+	})
+	waitForMessage(t, messages, "the despawn", func(message *pb.GameMessage) bool {
+		return message.GetDespawnMonster().GetMonsterId() == id
+	})
+	if _, alive := mm.MonsterStatus(id); alive {
+		t.Fatal("monster still alive at 0 HP")
+	}
+	if seesMonster("hunter", id) {
+		t.Fatal("dead monster is still in the AOI grid")
+	}
+
+	mm.Update(100 * time.Millisecond)
+	if _, alive := mm.MonsterStatus(id); alive {
+		t.Fatal("monster respawned before its respawn delay")
+	}
+
+	mm.Update(150 * time.Millisecond)
+	status, alive := mm.MonsterStatus(id)
+	if !alive {
+		t.Fatal("monster didn't respawn after its respawn delay")
+	}
+	if status.HP != template.MaxHP {
+		t.Fatalf("respawned with %d HP, want %d", status.HP, template.MaxHP)
+	}
+	if distance := status.Pos.DistanceXZ(center); distance > 1 {
+		t.Fatalf("respawned at %v, %.2f from the spawn center with radius 1", status.Pos, distance)
+	}
+	waitForMessage(t, messages, "the respawn", func(message *pb.GameMessage) bool {
+		return message.GetSpawnMonster().GetMonsterId() == id
+	})
+	if !seesMonster("hunter", id) {
+		t.Fatal("respawned monster isn't in the AOI grid")
+	}
+}
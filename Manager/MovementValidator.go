@@ -0,0 +1,154 @@
+package manager
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"testServer/config"
+	"testServer/vecmath"
+
+	pb "testServer/Messages"
+)
+
+// MovementPolicy decides what happens to a move that covers more ground than the player is allowed
+type MovementPolicy int
+
+const (
+	// 이동을 거부하고 마지막 위치로 되돌린다
+	MovementCorrect MovementPolicy = iota
+	// 허용된 거리만큼만 이동시키고 잘린 위치를 알려준다
+	MovementClamp
+)
+
+// ParseMovementPolicy converts the config name ("correct", "clamp") to a policy.
+// Unknown names fall back to MovementCorrect.
+func ParseMovementPolicy(name string) MovementPolicy {
+	if name == "clamp" {
+		return MovementClamp
+	}
+	return MovementCorrect
+}
+
+// moveState is the validation state kept per player. Guarded by Player.mu.
+type moveState struct {
+	lastMove   time.Time
+	budget     float32
+	violations uint64
+}
+
+// MovementValidator checks PlayerPosition updates against the player's last accepted
+// position, the time since then, the max speed and the navmesh, including its height
+type MovementValidator struct {
+	maxSpeed        float32
+	maxBudget       float32
+	heightTolerance float32
+	policy          MovementPolicy
+}
+
+func newMovementValidator(cfg config.MovementConfig) *MovementValidator {
+	maxSpeed := cfg.MaxSpeed * cfg.SpeedTolerance
+	return &MovementValidator{
+		maxSpeed:        maxSpeed,
+		maxBudget:       maxSpeed * float32(time.Duration(cfg.Burst).Seconds()),
+		heightTolerance: cfg.HeightTolerance,
+		policy:          ParseMovementPolicy(cfg.OnViolation),
+	}
+}
+
+// newMoveState returns the state of a player that just spawned at now
+func (mv *MovementValidator) newMoveState(now time.Time) moveState {
+	return moveState{lastMove: now, budget: mv.maxBudget}
+}
+
+// Move validates position and applies it to the player. A clamped move is written back
+// into position. Every failed check counts as a violation, and a rejected or clamped move
+// sends the player a PositionCorrection. It reports whether the player's position changed.
+func (mv *MovementValidator) Move(player *Player, position *pb.PlayerPosition, now time.Time) bool {
+	player.mu.Lock()
+	moved, corrected, reason := mv.move(player, position, now)
+	if reason != "" {
+		player.movement.violations++
+	}
+	var correction *pb.GameMessage
+	if corrected {
+		correction = &pb.GameMessage{
+			Message: &pb.GameMessage_PositionCorrection{
+				PositionCorrection: &pb.PositionCorrection{
+					X:         player.Pos.X,
+					Y:         player.Pos.Y,
+					Z:         player.Pos.Z,
+					RotationY: player.RotationY,
+					Reason:    reason,
+				},
+			},
+		}
+	}
+	player.mu.Unlock()
+
+	if correction != nil {
+		player.Send(correction)
+	}
+	return moved
+}
+
+// move does the actual checks. player.mu must be held.
+func (mv *MovementValidator) move(player *Player, position *pb.PlayerPosition, now time.Time) (moved, corrected bool, reason string) {
+	// NaN은 모든 비교를 통과하므로 예산이나 네비메시 검사보다 먼저 걸러낸다
+	for _, field := range []struct {
+		name  string
+		value float32
+	}{
+		{"x", position.X},
+		{"y", position.Y},
+		{"z", position.Z},
+		{"rotation_y", position.RotationY},
+		{"speed", position.Speed},
+	} {
+		if !isFinite(field.value) {
+			return false, true, fmt.Sprintf("%s is %v", field.name, field.value)
+		}
+	}
+
+	// 지난 이동 이후 흐른 시간만큼 이동 가능 거리를 채운다. 몰려서 도착한 패킷을 위해 burst만큼은 모아 둘 수 있다.
+	state := &player.movement
+	elapsed := float32(now.Sub(state.lastMove).Seconds())
+	state.lastMove = now
+	state.budget = min(state.budget+mv.maxSpeed*elapsed, mv.maxBudget)
+
+	if position.Speed > mv.maxSpeed {
+		reason = fmt.Sprintf("speed %.2f exceeds %.2f", position.Speed, mv.maxSpeed)
+	}
+
+	// 이동 거리는 바닥 평면에서 잰다. 점프나 경사로 인한 높이 변화는 속도 제한에 들어가지 않는다.
+	destination := vecmath.New(position.X, position.Y, position.Z)
+	distance := player.Pos.DistanceXZ(destination)
+	if distance > state.budget {
+		reason = fmt.Sprintf("moved %.2f, allowed %.2f", distance, state.budget)
+		if mv.policy != MovementClamp {
+			return false, true, reason
+		}
+		destination = player.Pos.Lerp(destination, state.budget/distance)
+		position.X, position.Y, position.Z = destination.X, destination.Y, destination.Z
+		distance = state.budget
+		corrected = true
+	}
+
+	height, onMesh := GetNavMeshManager().Height(destination)
+	if !onMesh {
+		return false, true, "destination is off the navmesh"
+	}
+	if offset := destination.Y - height; offset > mv.heightTolerance || offset < -mv.heightTolerance {
+		return false, true, fmt.Sprintf("height %.2f is %.2f from the navmesh", destination.Y, offset)
+	}
+
+	// 속도 값만 틀린 경우는 위치를 고칠 필요가 없으므로 보정을 보내지 않는다
+	state.budget -= distance
+	player.Pos, player.RotationY = destination, position.RotationY
+	return true, corrected, reason
+}
+
+// isFinite reports whether v is neither NaN nor infinite
+func isFinite(v float32) bool {
+	return !math.IsNaN(float64(v)) && !math.IsInf(float64(v), 0)
+}
@@ -0,0 +1,217 @@
+package manager
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"testServer/config"
+	"testServer/vecmath"
+
+	pb "testServer/Messages"
+)
+
+func TestMovementValidator(t *testing.T) {
+	type step struct {
+		after     time.Duration
+		to        vecmath.Vec3
+		speed     float32
+		wantMoved bool
+		wantPos   vecmath.Vec3
+	}
+
+	// 초당 4만큼, 최대 1초치(4)까지 모아 둘 수 있다. 테스트 네비메시는 높이 0의 ±1000 정사각형이다.
+	tests := []struct {
+		name            string
+		policy          string
+		start           vecmath.Vec3
+		steps           []step
+		wantViolations  uint64
+		wantCorrections int
+	}{
+		{
+			name:   "within budget",
+			policy: "correct",
+			steps: []step{
+				{0, vecmath.New(3, 0, 0), 0, true, vecmath.New(3, 0, 0)},
+			},
+		},
+		{
+			name:   "budget is spent and refills over time",
+			policy: "correct",
+			steps: []step{
+				{0, vecmath.New(4, 0, 0), 0, true, vecmath.New(4, 0, 0)},
+				{0, vecmath.New(5, 0, 0), 0, false, vecmath.New(4, 0, 0)},
+				{500 * time.Millisecond, vecmath.New(6, 0, 0), 0, true, vecmath.New(6, 0, 0)},
+			},
+			wantViolations:  1,
+			wantCorrections: 1,
+		},
+		{
+			name:   "saved budget is capped at burst",
+			policy: "correct",
+			steps: []step{
+				{10 * time.Second, vecmath.New(0, 0, 8), 0, false, vecmath.Vec3{}},
+				{10 * time.Second, vecmath.New(0, 0, 4), 0, true, vecmath.New(0, 0, 4)},
+			},
+			wantViolations:  1,
+			wantCorrections: 1,
+		},
+		{
+			name:   "height changes are free",
+			policy: "correct",
+			steps: []step{
+				{0, vecmath.New(4, 1.5, 0), 0, true, vecmath.New(4, 1.5, 0)},
+			},
+		},
+		{
+			name:   "correct rejects a long move",
+			policy: "correct",
+			steps: []step{
+				{0, vecmath.New(10, 0, 0), 0, false, vecmath.Vec3{}},
+			},
+			wantViolations:  1,
+			wantCorrections: 1,
+		},
+		{
+			name:   "clamp cuts a long move short",
+			policy: "clamp",
+			steps: []step{
+				{0, vecmath.New(0, 0, -10), 0, true, vecmath.New(0, 0, -4)},
+				{0, vecmath.New(0, 0, -5), 0, true, vecmath.New(0, 0, -4)},
+			},
+			wantViolations:  2,
+			wantCorrections: 2,
+		},
+		{
+			name:   "speed field alone is only counted",
+			policy: "correct",
+			steps: []step{
+				{0, vecmath.New(1, 0, 0), 100, true, vecmath.New(1, 0, 0)},
+			},
+			wantViolations: 1,
+		},
+		{
+			name:   "too far above the navmesh",
+			policy: "clamp",
+			steps: []step{
+				{0, vecmath.New(1, 3, 0), 0, false, vecmath.Vec3{}},
+				{0, vecmath.New(1, -3, 0), 0, false, vecmath.Vec3{}},
+			},
+			wantViolations:  2,
+			wantCorrections: 2,
+		},
+		{
+			name:   "off the navmesh",
+			policy: "clamp",
+			start:  vecmath.New(999, 0, 0),
+			steps: []step{
+				{0, vecmath.New(1001, 0, 0), 0, false, vecmath.New(999, 0, 0)},
+			},
+			wantViolations:  1,
+			wantCorrections: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mv := newMovementValidator(config.MovementConfig{
+				MaxSpeed:        4,
+				SpeedTolerance:  1,
+				Burst:           config.Duration(time.Second),
+				HeightTolerance: 2,
+				OnViolation:     tt.policy,
+			})
+			session, messages := newTestClient(t)
+			start := time.Unix(1000, 0)
+			player := &Player{Name: "mover", session: session, Pos: tt.start, movement: mv.newMoveState(start)}
+
+			now := start
+			for i, s := range tt.steps {
+				now = now.Add(s.after)
+				position := &pb.PlayerPosition{X: s.to.X, Y: s.to.Y, Z: s.to.Z, Speed: s.speed}
+				if moved := mv.Move(player, position, now); moved != s.wantMoved {
+					t.Fatalf("step %d: moved = %v, want %v", i, moved, s.wantMoved)
+				}
+				if pos := player.Position(); pos.DistanceXZ(s.wantPos) > 1e-4 || pos.Y != s.wantPos.Y {
+					t.Fatalf("step %d: player at %v, want %v", i, pos, s.wantPos)
+				}
+			}
+			if violations := player.Violations(); violations != tt.wantViolations {
+				t.Fatalf("violations = %d, want %d", violations, tt.wantViolations)
+			}
+
+			// 보정은 세션으로 비동기 전송되므로 기대한 수만큼 받은 뒤 잠시 더 기다려 본다
+			corrections := 0
+			timeout := time.After(time.Second)
+		read:
+			for corrections <= tt.wantCorrections {
+				wait := timeout
+				if corrections == tt.wantCorrections {
+					wait = time.After(50 * time.Millisecond)
+				}
+				select {
+				case message := <-messages:
+					if correction := message.GetPositionCorrection(); correction != nil {
+						corrections++
+						if correction.Reason == "" {
+							t.Fatalf("correction to (%v, %v) has no reason", correction.X, correction.Z)
+						}
+					}
+				case <-wait:
+					break read
+				}
+			}
+			if corrections != tt.wantCorrections {
+				t.Fatalf("sent %d corrections, want %d", corrections, tt.wantCorrections)
+			}
+		})
+	}
+}
+
+func TestMovementValidatorRejectsNonFiniteValues(t *testing.T) {
+	nan := float32(math.NaN())
+	inf := float32(math.Inf(1))
+
+	tests := []struct {
+		name     string
+		position *pb.PlayerPosition
+	}{
+		{"NaN x", &pb.PlayerPosition{X: nan}},
+		{"NaN y", &pb.PlayerPosition{X: 1, Y: nan}},
+		{"NaN z", &pb.PlayerPosition{Z: nan}},
+		{"NaN rotation", &pb.PlayerPosition{X: 1, RotationY: nan}},
+		{"NaN speed", &pb.PlayerPosition{X: 1, Speed: nan}},
+		{"+Inf x", &pb.PlayerPosition{X: inf}},
+		{"-Inf y", &pb.PlayerPosition{X: 1, Y: -inf}},
+		{"+Inf z", &pb.PlayerPosition{Z: inf}},
+		{"-Inf rotation", &pb.PlayerPosition{X: 1, RotationY: -inf}},
+		{"+Inf speed", &pb.PlayerPosition{X: 1, Speed: inf}},
+	}
+
+	for _, tt := range tests {
+		for _, policy := range []string{"correct", "clamp"} {
+			t.Run(tt.name+" "+policy, func(t *testing.T) {
+				mv := newMovementValidator(config.MovementConfig{
+					MaxSpeed:        4,
+					SpeedTolerance:  1,
+					Burst:           config.Duration(time.Second),
+					HeightTolerance: 2,
+					OnViolation:     policy,
+				})
+				now := time.Unix(1000, 0)
+				player := &Player{Name: "mover", session: newTestSession(t), movement: mv.newMoveState(now)}
+
+				if moved := mv.Move(player, tt.position, now); moved {
+					t.Fatal("move with a non-finite value was applied")
+				}
+				if pos, rotation := player.Transform(); pos != (vecmath.Vec3{}) || rotation != 0 {
+					t.Fatalf("player moved to %v facing %v", pos, rotation)
+				}
+				if violations := player.Violations(); violations != 1 {
+					t.Fatalf("violations = %d, want 1", violations)
+				}
+			})
+		}
+	}
+}
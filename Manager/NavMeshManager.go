@@ -3,7 +3,13 @@ package manager
 import (
 	"encoding/json"
 	"fmt"
+	"log"
+	"math"
 	"os"
+	"sync"
+
+	"testServer/config"
+	"testServer/vecmath"
 
 	n "github.com/hqpko/navmesh"
 )
@@ -17,37 +23,70 @@ type NavMeshJsonData struct {
 	Triangles []Triangle `json:"triangles"`
 }
 
+// 위치 검사용 격자 한 칸의 크기. 삼각형은 바운딩 박스가 걸치는 모든 칸에 들어간다.
+const navCellSize = 10.0
+
+type navCell struct {
+	X, Y int32
+}
+
 type NavMeshManager struct {
+	// 경로 탐색은 로그인 처리와 월드 루프 양쪽에서 호출되므로 한 번에 하나씩만 돌린다
+	mu      sync.Mutex
 	navMesh *n.NavMesh
+	path    string
+	cells   map[navCell][]int
 }
 
-var navMeshManager *NavMeshManager
+var (
+	navMeshManager     *NavMeshManager
+	navMeshManagerOnce sync.Once
+)
 
 func GetNavMeshManager() *NavMeshManager {
-	if navMeshManager == nil {
-		navMeshManager = &NavMeshManager{
-			navMesh: &n.NavMesh{},
+	navMeshManagerOnce.Do(func() {
+		var err error
+		navMeshManager, err = newNavMeshManager(config.Default().NavMesh)
+		if err != nil {
+			log.Printf("Navmesh not loaded: %v", err)
 		}
-		navMeshManager.LoadNavMeshData()
-	}
+	})
 
 	return navMeshManager
 }
 
-func (nm *NavMeshManager) PathFinding(srcX float64, srcY float64, srcZ float64,
-	destX float64, destY float64, destZ float64) (*n.Path, error) {
-	return nm.navMesh.FindingPath(&n.V3{X: srcX, Y: srcZ, Z: srcY}, &n.V3{
-		X: destX,
-		Y: destZ,
-		Z: destY,
-	})
+// newNavMeshManager loads the navmesh at cfg.Path. The manager is returned even when
+// loading fails, without a mesh.
+func newNavMeshManager(cfg config.NavMeshConfig) (*NavMeshManager, error) {
+	nm := &NavMeshManager{
+		navMesh: &n.NavMesh{},
+		path:    cfg.Path,
+	}
+	return nm, nm.LoadNavMeshData()
+}
+
+// PathFinding finds a path on the navmesh between two game-space points and returns
+// its waypoints in game space
+func (nm *NavMeshManager) PathFinding(from, to vecmath.Vec3) ([]vecmath.Vec3, error) {
+	nm.mu.Lock()
+	path, err := nm.navMesh.FindingPath(toNav(from), toNav(to))
+	nm.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]vecmath.Vec3, 0, len(path.PathList))
+	for _, v := range path.PathList {
+		points = append(points, fromNav(v))
+	}
+	return points, nil
 }
 
-func (nm *NavMeshManager) LoadNavMeshData() {
-	file, err := os.Open("NavMeshData.json")
+// LoadNavMeshData reads the navmesh file at the configured path
+func (nm *NavMeshManager) LoadNavMeshData() error {
+	file, err := os.Open(nm.path)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		return fmt.Errorf("open navmesh: %w", err)
 	}
 	defer file.Close()
 
@@ -60,8 +99,18 @@ func (nm *NavMeshManager) LoadNavMeshData() {
 	// JSON 파일 디코딩
 	err = decoder.Decode(&navMeshData)
 	if err != nil {
-		fmt.Println("Error decoding JSON:", err)
-		return
+		return fmt.Errorf("decode navmesh %s: %w", nm.path, err)
+	}
+
+	if len(navMeshData.Triangles) == 0 {
+		return fmt.Errorf("navmesh %s has no triangles", nm.path)
+	}
+	for i, triangle := range navMeshData.Triangles {
+		for _, index := range triangle.Indices {
+			if index < 0 || index >= len(navMeshData.Vertices) {
+				return fmt.Errorf("navmesh %s: triangle %d uses vertex %d of %d", nm.path, i, index, len(navMeshData.Vertices))
+			}
+		}
 	}
 
 	nm.navMesh.Vertices = navMeshData.Vertices
@@ -77,6 +126,98 @@ func (nm *NavMeshManager) LoadNavMeshData() {
 	}
 
 	nm.navMesh.Dijkstra.CreateMatrixFromMesh(navMeshData.Vertices, nm.navMesh.Triangles)
+	nm.buildCells()
+
+	log.Printf("Loaded navmesh %s with %d triangles", nm.path, len(nm.navMesh.Triangles))
+	return nil
+}
+
+// FindPath implements behavior.PathFinder. Without a loaded mesh the path is a straight line.
+func (nm *NavMeshManager) FindPath(from, to vecmath.Vec3) ([]vecmath.Vec3, error) {
+	if len(nm.navMesh.Triangles) == 0 {
+		return []vecmath.Vec3{to}, nil
+	}
+	return nm.PathFinding(from, to)
+}
+
+// Contains reports whether the game-space point p lies on the navmesh, ignoring its height.
+// No point is on the navmesh when no mesh is loaded.
+func (nm *NavMeshManager) Contains(p vecmath.Vec3) bool {
+	_, ok := nm.Height(p)
+	return ok
+}
+
+// Height returns the game-space height of the navmesh under p, or false when p is off the mesh
+func (nm *NavMeshManager) Height(p vecmath.Vec3) (float32, bool) {
+	v := toNav(p)
+	for _, i := range nm.cells[navCellOf(v.X, v.Y)] {
+		a, b, c := nm.triangle(i)
+		if pointInTriangle(v.X, v.Y, a, b, c) {
+			return float32(heightInTriangle(v.X, v.Y, a, b, c)), true
+		}
+	}
+	return 0, false
+}
+
+// buildCells indexes every triangle by the grid cells its bounding box overlaps
+func (nm *NavMeshManager) buildCells() {
+	nm.cells = make(map[navCell][]int)
+	for i := range nm.navMesh.Triangles {
+		a, b, c := nm.triangle(i)
+		lo := navCellOf(math.Min(a.X, math.Min(b.X, c.X)), math.Min(a.Y, math.Min(b.Y, c.Y)))
+		hi := navCellOf(math.Max(a.X, math.Max(b.X, c.X)), math.Max(a.Y, math.Max(b.Y, c.Y)))
+		for cx := lo.X; cx <= hi.X; cx++ {
+			for cy := lo.Y; cy <= hi.Y; cy++ {
+				cell := navCell{X: cx, Y: cy}
+				nm.cells[cell] = append(nm.cells[cell], i)
+			}
+		}
+	}
+}
+
+func (nm *NavMeshManager) triangle(i int) (a, b, c *n.V3) {
+	indices := nm.navMesh.Triangles[i]
+	return nm.navMesh.Vertices[indices[0]], nm.navMesh.Vertices[indices[1]], nm.navMesh.Vertices[indices[2]]
+}
+
+// 네비메시는 X/Y가 바닥 평면이고 Z가 높이다. 게임 좌표(Y가 높이)와는 Y/Z가 바뀌어 있으므로
+// 네비메시에 들어가고 나오는 좌표는 모두 toNav/fromNav를 거친다.
+func toNav(v vecmath.Vec3) *n.V3 {
+	return &n.V3{X: float64(v.X), Y: float64(v.Z), Z: float64(v.Y)}
+}
+
+func fromNav(v *n.V3) vecmath.Vec3 {
+	return vecmath.Vec3{X: float32(v.X), Y: float32(v.Z), Z: float32(v.Y)}
+}
+
+func navCellOf(x, y float64) navCell {
+	return navCell{
+		X: int32(math.Floor(x / navCellSize)),
+		Y: int32(math.Floor(y / navCellSize)),
+	}
+}
+
+// heightInTriangle interpolates the navmesh Z of the triangle at (x, y)
+func heightInTriangle(x, y float64, a, b, c *n.V3) float64 {
+	det := (b.Y-c.Y)*(a.X-c.X) + (c.X-b.X)*(a.Y-c.Y)
+	if math.Abs(det) < 1e-12 {
+		// 평면에서 보면 선분으로 찌그러진 삼각형은 가장 높은 꼭짓점을 쓴다
+		return math.Max(a.Z, math.Max(b.Z, c.Z))
+	}
+	wa := ((b.Y-c.Y)*(x-c.X) + (c.X-b.X)*(y-c.Y)) / det
+	wb := ((c.Y-a.Y)*(x-c.X) + (a.X-c.X)*(y-c.Y)) / det
+	return wa*a.Z + wb*b.Z + (1-wa-wb)*c.Z
+}
 
-	print(len(nm.navMesh.Triangles))
+// pointInTriangle tests (x, y) against the triangle projected onto the navmesh X/Y plane.
+// Points on an edge count as inside.
+func pointInTriangle(x, y float64, a, b, c *n.V3) bool {
+	const epsilon = 1e-6
+	d1 := (x-b.X)*(a.Y-b.Y) - (a.X-b.X)*(y-b.Y)
+	d2 := (x-c.X)*(b.Y-c.Y) - (b.X-c.X)*(y-c.Y)
+	d3 := (x-a.X)*(c.Y-a.Y) - (c.X-a.X)*(y-a.Y)
+
+	hasNeg := d1 < -epsilon || d2 < -epsilon || d3 < -epsilon
+	hasPos := d1 > epsilon || d2 > epsilon || d3 > epsilon
+	return !(hasNeg && hasPos)
 }
@@ -0,0 +1,101 @@
+package manager
+
+import (
+	"math"
+	"testing"
+
+	"testServer/config"
+	"testServer/vecmath"
+
+	n "github.com/hqpko/navmesh"
+)
+
+func TestNavAxisMapping(t *testing.T) {
+	// 게임 좌표는 Y가 높이, 네비메시 좌표는 Z가 높이다
+	game := vecmath.New(1, 2, 3)
+	nav := toNav(game)
+	if nav.X != 1 || nav.Y != 3 || nav.Z != 2 {
+		t.Fatalf("toNav(%v) = %+v, want {X:1 Y:3 Z:2}", game, *nav)
+	}
+	if got := fromNav(nav); got != game {
+		t.Fatalf("fromNav(toNav(%v)) = %v", game, got)
+	}
+}
+
+func TestContainsUsesGroundPlane(t *testing.T) {
+	// 네비메시 좌표로 높이 50에 놓인, X축을 따라 가늘고 긴 삼각형
+	nm := &NavMeshManager{navMesh: &n.NavMesh{
+		Vertices:  []*n.V3{{X: 0, Y: 0, Z: 50}, {X: 10, Y: 0, Z: 50}, {X: 10, Y: 2, Z: 50}},
+		Triangles: [][3]int32{{0, 1, 2}},
+	}}
+	nm.buildCells()
+
+	tests := []struct {
+		name  string
+		point vecmath.Vec3
+		want  bool
+	}{
+		{"on the mesh", vecmath.New(9, 50, 1), true},
+		{"height is ignored", vecmath.New(9, 0, 1), true},
+		{"x and z swapped", vecmath.New(1, 50, 9), false},
+		{"height used as z", vecmath.New(9, 1, 50), false},
+		{"outside", vecmath.New(-1, 50, 1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nm.Contains(tt.point); got != tt.want {
+				t.Errorf("Contains(%v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestNavMesh returns a flat square navmesh of half width size at game height 0
+func newTestNavMesh(size float64) *NavMeshManager {
+	nm := &NavMeshManager{navMesh: &n.NavMesh{
+		Vertices: []*n.V3{
+			{X: -size, Y: -size}, {X: size, Y: -size}, {X: size, Y: size}, {X: -size, Y: size},
+		},
+		Triangles: [][3]int32{{0, 1, 2}, {0, 2, 3}},
+	}}
+	nm.buildCells()
+	return nm
+}
+
+func TestHeightFollowsSlope(t *testing.T) {
+	// 게임 좌표 X를 따라 0에서 10까지 올라가는 경사면
+	nm := &NavMeshManager{navMesh: &n.NavMesh{
+		Vertices:  []*n.V3{{X: 0, Y: 0, Z: 0}, {X: 10, Y: 0, Z: 10}, {X: 10, Y: 10, Z: 10}, {X: 0, Y: 10, Z: 0}},
+		Triangles: [][3]int32{{0, 1, 2}, {0, 2, 3}},
+	}}
+	nm.buildCells()
+
+	tests := []struct {
+		point  vecmath.Vec3
+		want   float32
+		onMesh bool
+	}{
+		{vecmath.New(0, 0, 0), 0, true},
+		{vecmath.New(5, 0, 2), 5, true},
+		{vecmath.New(5, 99, 8), 5, true},
+		{vecmath.New(10, 0, 10), 10, true},
+		{vecmath.New(11, 0, 5), 0, false},
+	}
+	for _, tt := range tests {
+		height, onMesh := nm.Height(tt.point)
+		if onMesh != tt.onMesh || (onMesh && math.Abs(float64(height-tt.want)) > 1e-4) {
+			t.Errorf("Height(%v) = %v, %v, want %v, %v", tt.point, height, onMesh, tt.want, tt.onMesh)
+		}
+	}
+}
+
+func TestMissingNavMeshContainsNothing(t *testing.T) {
+	nm, err := newNavMeshManager(config.NavMeshConfig{Path: "does-not-exist.json"})
+	if err == nil {
+		t.Fatal("loading a missing navmesh succeeded")
+	}
+	if nm.Contains(vecmath.New(0, 0, 0)) {
+		t.Fatal("a manager without a mesh accepted a point")
+	}
+}
@@ -1,37 +1,133 @@
 package manager
 
 import (
-	"encoding/binary"
+	"errors"
+	"io"
 	"log"
+	"net"
+	"os"
+	"sync"
+	"time"
 
-	pb "testServer/Messages"
+	"testServer/config"
 
-	"google.golang.org/protobuf/proto"
+	pb "testServer/Messages"
 )
 
 type NetManager struct {
+	maxPacketSize int
+	sendQueueSize int
+	backpressure  BackpressurePolicy
+	writeTimeout  time.Duration
+	pingInterval  time.Duration
+	idleTimeout   time.Duration
 }
 
-var netManager *NetManager
+var (
+	netManager     *NetManager
+	netManagerOnce sync.Once
+)
 
 func GetNetManager() *NetManager {
-	if netManager == nil {
-		netManager = &NetManager{}
-	}
+	netManagerOnce.Do(func() {
+		netManager = newNetManager(config.Default().Server)
+	})
 
 	return netManager
 }
 
+func newNetManager(cfg config.ServerConfig) *NetManager {
+	return &NetManager{
+		maxPacketSize: cfg.MaxPacketSize,
+		sendQueueSize: cfg.SendQueueSize,
+		backpressure:  ParseBackpressurePolicy(cfg.Backpressure),
+		writeTimeout:  time.Duration(cfg.WriteTimeout),
+		pingInterval:  time.Duration(cfg.PingInterval),
+		idleTimeout:   time.Duration(cfg.IdleTimeout),
+	}
+}
+
+// MaxPacketSize returns the largest frame body accepted or produced by the server
+func (nm *NetManager) MaxPacketSize() int {
+	return nm.maxPacketSize
+}
+
+// NewSession creates a session for conn using the configured queue size and backpressure policy
+func (nm *NetManager) NewSession(conn net.Conn) *Session {
+	session := NewSession(conn, nm.sendQueueSize, nm.backpressure)
+	session.writeTimeout = nm.writeTimeout
+	session.pingInterval = nm.pingInterval
+	return session
+}
+
+// IdleTimeout returns how long a connection may stay silent before it is dropped (0 disables it)
+func (nm *NetManager) IdleTimeout() time.Duration {
+	return nm.idleTimeout
+}
+
+// RegisterHandlers registers the connection-level message handlers
+func (nm *NetManager) RegisterHandlers(d *Dispatcher) {
+	d.Handle((*pb.GameMessage_Ping)(nil), nm.handlePing)
+	d.Handle((*pb.GameMessage_Pong)(nil), nm.handlePong)
+}
+
+// handlePing echoes the client's Ping so it can measure its own RTT
+func (nm *NetManager) handlePing(session *Session, message *pb.GameMessage) {
+	ping := message.GetPing()
+	session.Send(&pb.GameMessage{
+		Message: &pb.GameMessage_Pong{
+			Pong: &pb.Pong{Sequence: ping.Sequence, SentAt: ping.SentAt},
+		},
+	})
+}
+
+func (nm *NetManager) handlePong(session *Session, message *pb.GameMessage) {
+	session.recordPong(message.GetPong())
+}
+
+// NewReader creates a PacketReader using the server's frame size limit
+func (nm *NetManager) NewReader(conn net.Conn) *PacketReader {
+	return NewPacketReader(conn, nm.maxPacketSize)
+}
+
+// ReadLoop reads messages from session's connection and passes each one to handle until
+// the connection fails or is closed, and returns the error that ended it. A connection
+// that sends nothing, not even a Pong, for the idle timeout is dropped.
+func (nm *NetManager) ReadLoop(session *Session, handle HandlerFunc) error {
+	reader := nm.NewReader(session.conn)
+	for {
+		// 일정 시간 동안 아무 메시지도 오지 않으면 (Pong 포함) 읽기가 실패하고 연결을 끊는다
+		if nm.idleTimeout > 0 {
+			session.conn.SetReadDeadline(time.Now().Add(nm.idleTimeout))
+		}
+
+		// 길이 헤더와 본문을 끝까지 읽어 메시지 하나를 파싱한다
+		message, err := reader.ReadMessage()
+		if err != nil {
+			var decodeErr *DecodeError
+			if errors.As(err, &decodeErr) {
+				log.Printf("Failed to unmarshal message: %v", err)
+				continue
+			}
+			switch {
+			case errors.Is(err, os.ErrDeadlineExceeded):
+				log.Printf("Session %d idle for %v, disconnecting", session.ID(), nm.idleTimeout)
+			case !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed):
+				log.Printf("Failed to read message: %v", err)
+			}
+			return err
+		}
+
+		handle(session, message)
+	}
+}
+
 func (nm *NetManager) MakePacket(msg *pb.GameMessage) []byte {
-	response, err := proto.Marshal(msg)
+	packet, err := AppendPacket(nil, msg, nm.maxPacketSize)
 	if err != nil {
 		log.Printf("Failed to marshal response: %v", err)
 		return nil
 	}
 
-	lengthBuf := make([]byte, 4)
-	binary.LittleEndian.PutUint32(lengthBuf, uint32(len(response)))
-	lengthBuf = append(lengthBuf, response...)
-
-	return lengthBuf
+	return packet
 }
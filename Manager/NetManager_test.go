@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"testServer/config"
+
+	pb "testServer/Messages"
+)
+
+func TestReadLoopKeepsAnsweringClientAndDropsIdleOne(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.PingInterval = config.Duration(20 * time.Millisecond)
+	cfg.IdleTimeout = config.Duration(100 * time.Millisecond)
+	nm := newNetManager(cfg)
+
+	server, client := net.Pipe()
+	defer client.Close()
+	session := nm.NewSession(server)
+	session.Start()
+	defer session.Close()
+
+	var pongs atomic.Int32
+	result := make(chan error, 1)
+	go func() {
+		result <- nm.ReadLoop(session, func(session *Session, message *pb.GameMessage) {
+			if pong := message.GetPong(); pong != nil {
+				session.recordPong(pong)
+				pongs.Add(1)
+			}
+		})
+	}()
+
+	// 클라이언트는 300ms 동안 Ping마다 Pong으로 답하다가 멈춘다
+	answering := time.Now().Add(300 * time.Millisecond)
+	reader := NewPacketReader(client, 0)
+	for time.Now().Before(answering) {
+		client.SetReadDeadline(time.Now().Add(time.Second))
+		message, err := reader.ReadMessage()
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		ping := message.GetPing()
+		if ping == nil {
+			continue
+		}
+		pong := GetNetManager().MakePacket(&pb.GameMessage{
+			Message: &pb.GameMessage_Pong{Pong: &pb.Pong{Sequence: ping.Sequence, SentAt: ping.SentAt}},
+		})
+		if _, err := client.Write(pong); err != nil {
+			t.Fatalf("write pong: %v", err)
+		}
+	}
+
+	select {
+	case err := <-result:
+		t.Fatalf("connection dropped while the client answered pings: %v", err)
+	default:
+	}
+	if pongs.Load() == 0 {
+		t.Fatal("no pong reached the handler")
+	}
+	if last, _ := session.RTT(); last == 0 {
+		t.Fatal("rtt not measured")
+	}
+
+	// 더 이상 읽지도 답하지도 않으면 idle timeout 뒤에 끊어야 한다
+	select {
+	case err := <-result:
+		if !errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Fatalf("ReadLoop ended with %v, want %v", err, os.ErrDeadlineExceeded)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("idle connection was never dropped")
+	}
+}
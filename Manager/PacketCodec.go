@@ -0,0 +1,131 @@
+package manager
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	pb "testServer/Messages"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// 패킷 구조: [4바이트 little-endian 길이][protobuf 본문]
+const (
+	PacketHeaderSize     = 4
+	DefaultMaxPacketSize = 64 * 1024
+)
+
+var ErrPacketTooLarge = errors.New("packet too large")
+
+// FrameError is returned when the length-prefixed stream itself is broken.
+// The connection can't be resynchronized and should be closed.
+type FrameError struct {
+	Err error
+}
+
+func (e *FrameError) Error() string {
+	return fmt.Sprintf("frame error: %v", e.Err)
+}
+
+func (e *FrameError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is returned when a frame was read intact but its body is not a
+// valid GameMessage. The stream is still aligned, so the caller may continue.
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode error: %v", e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// PacketReader reads length-prefixed GameMessages from a stream
+type PacketReader struct {
+	r       io.Reader
+	maxSize int
+	header  [PacketHeaderSize]byte
+	buf     []byte
+}
+
+// NewPacketReader creates a PacketReader. maxSize <= 0 uses DefaultMaxPacketSize.
+func NewPacketReader(r io.Reader, maxSize int) *PacketReader {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxPacketSize
+	}
+	return &PacketReader{r: r, maxSize: maxSize}
+}
+
+// ReadFrame reads one frame body. The returned slice is only valid until the next call.
+func (pr *PacketReader) ReadFrame() ([]byte, error) {
+	if _, err := io.ReadFull(pr.r, pr.header[:]); err != nil {
+		// 프레임 경계에서의 EOF는 정상 종료
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, &FrameError{Err: err}
+	}
+
+	length := binary.LittleEndian.Uint32(pr.header[:])
+	if uint64(length) > uint64(pr.maxSize) {
+		return nil, &FrameError{Err: fmt.Errorf("%w: %d > %d", ErrPacketTooLarge, length, pr.maxSize)}
+	}
+
+	// 버퍼는 재사용하고 더 큰 패킷이 올 때만 늘린다
+	if cap(pr.buf) < int(length) {
+		pr.buf = make([]byte, length)
+	}
+	pr.buf = pr.buf[:length]
+
+	if _, err := io.ReadFull(pr.r, pr.buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, &FrameError{Err: err}
+	}
+
+	return pr.buf, nil
+}
+
+// ReadMessage reads and decodes the next GameMessage
+func (pr *PacketReader) ReadMessage() (*pb.GameMessage, error) {
+	frame, err := pr.ReadFrame()
+	if err != nil {
+		return nil, err
+	}
+
+	message := &pb.GameMessage{}
+	if err := proto.Unmarshal(frame, message); err != nil {
+		return nil, &DecodeError{Err: err}
+	}
+	return message, nil
+}
+
+// AppendPacket appends the framed encoding of msg to dst
+func AppendPacket(dst []byte, msg *pb.GameMessage, maxSize int) ([]byte, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxPacketSize
+	}
+
+	start := len(dst)
+	dst = append(dst, 0, 0, 0, 0)
+	dst, err := proto.MarshalOptions{}.MarshalAppend(dst, msg)
+	if err != nil {
+		return dst[:start], err
+	}
+
+	length := len(dst) - start - PacketHeaderSize
+	if length > maxSize {
+		return dst[:start], fmt.Errorf("%w: %d > %d", ErrPacketTooLarge, length, maxSize)
+	}
+	binary.LittleEndian.PutUint32(dst[start:], uint32(length))
+
+	return dst, nil
+}
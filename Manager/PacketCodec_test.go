@@ -0,0 +1,116 @@
+package manager
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	pb "testServer/Messages"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func chatMessage(content string) *pb.GameMessage {
+	return &pb.GameMessage{
+		Message: &pb.GameMessage_Chat{Chat: &pb.ChatMessage{Sender: "tester", Content: content}},
+	}
+}
+
+func TestPacketRoundTrip(t *testing.T) {
+	want := []*pb.GameMessage{chatMessage("first"), chatMessage(""), chatMessage("third")}
+	var stream []byte
+	for _, message := range want {
+		var err error
+		if stream, err = AppendPacket(stream, message, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 한 바이트씩 도착해도 프레임이 온전히 읽혀야 한다
+	reader := NewPacketReader(iotest.OneByteReader(bytes.NewReader(stream)), 0)
+	for i, message := range want {
+		got, err := reader.ReadMessage()
+		if err != nil {
+			t.Fatalf("message %d: %v", i, err)
+		}
+		if !proto.Equal(got, message) {
+			t.Fatalf("message %d = %v, want %v", i, got, message)
+		}
+	}
+	if _, err := reader.ReadMessage(); err != io.EOF {
+		t.Fatalf("after the last frame got %v, want io.EOF", err)
+	}
+}
+
+func TestAppendPacketRejectsOversizeMessage(t *testing.T) {
+	dst := []byte{1, 2, 3}
+	got, err := AppendPacket(dst, chatMessage(string(make([]byte, 100))), 64)
+	if !errors.Is(err, ErrPacketTooLarge) {
+		t.Fatalf("err = %v, want ErrPacketTooLarge", err)
+	}
+	if !bytes.Equal(got, dst) {
+		t.Fatalf("dst = %v after a failed append, want it unchanged", got)
+	}
+}
+
+func TestPacketReaderErrors(t *testing.T) {
+	header := func(length uint32) []byte {
+		return binary.LittleEndian.AppendUint32(nil, length)
+	}
+
+	tests := []struct {
+		name       string
+		stream     []byte
+		wantFrame  bool
+		wantDecode bool
+		wantErr    error
+	}{
+		{"huge length header", header(0xFFFFFFFF), true, false, ErrPacketTooLarge},
+		{"just over max size", header(65), true, false, ErrPacketTooLarge},
+		{"truncated header", []byte{1, 0}, true, false, io.ErrUnexpectedEOF},
+		{"truncated body", append(header(10), 1, 2, 3), true, false, io.ErrUnexpectedEOF},
+		{"bad body", append(header(2), 0xFF, 0xFF), false, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewPacketReader(iotest.OneByteReader(bytes.NewReader(tt.stream)), 64)
+			_, err := reader.ReadMessage()
+
+			var frameErr *FrameError
+			var decodeErr *DecodeError
+			if got := errors.As(err, &frameErr); got != tt.wantFrame {
+				t.Fatalf("err = %v, FrameError = %v, want %v", err, got, tt.wantFrame)
+			}
+			if got := errors.As(err, &decodeErr); got != tt.wantDecode {
+				t.Fatalf("err = %v, DecodeError = %v, want %v", err, got, tt.wantDecode)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// 본문이 깨진 프레임 뒤에도 스트림은 정렬되어 있어 다음 메시지를 읽을 수 있다
+func TestPacketReaderContinuesAfterDecodeError(t *testing.T) {
+	stream := binary.LittleEndian.AppendUint32(nil, 2)
+	stream = append(stream, 0xFF, 0xFF)
+	stream, err := AppendPacket(stream, chatMessage("after"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader := NewPacketReader(bytes.NewReader(stream), 0)
+	var decodeErr *DecodeError
+	if _, err := reader.ReadMessage(); !errors.As(err, &decodeErr) {
+		t.Fatalf("first read err = %v, want DecodeError", err)
+	}
+	got, err := reader.ReadMessage()
+	if err != nil || got.GetChat().GetContent() != "after" {
+		t.Fatalf("second read = %v, %v, want the chat message", got, err)
+	}
+}
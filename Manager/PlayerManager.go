@@ -1,175 +1,392 @@
 package manager
 
 import (
-	"encoding/binary"
 	"errors"
 	"log"
-	"net"
+	"sync"
+	"time"
 
-	pb "testServer/Messages"
+	"testServer/config"
+	"testServer/vecmath"
 
-	"google.golang.org/protobuf/proto"
+	pb "testServer/Messages"
 )
 
-var playerManager *PlayerManager
+var (
+	playerManager     *PlayerManager
+	playerManagerOnce sync.Once
+)
 
 // Player represents a single player with some attributes
 type Player struct {
-	ID        int
-	Name      string
-	Age       int
-	Conn      *net.Conn
-	X         float32
-	Y         float32
-	Z         float32
-	RotationY float32
+	ID   int
+	Name string
+	Age  int
+
+	// 위치 정보와 세션은 여러 고루틴에서 접근하므로 mu로 보호한다
+	mu         sync.RWMutex
+	session    *Session
+	movement   moveState
+	lastAttack time.Time
+	Pos        vecmath.Vec3
+	RotationY  float32
+}
+
+// Session returns the session the player is currently connected on
+func (p *Player) Session() *Session {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.session
+}
+
+func (p *Player) setSession(session *Session) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.session = session
+}
+
+// Send queues msg on the player's current session
+func (p *Player) Send(msg *pb.GameMessage) error {
+	return p.Session().Send(msg)
+}
+
+// SendPacket queues an already framed packet on the player's current session
+func (p *Player) SendPacket(packet []byte) error {
+	return p.Session().SendPacket(packet)
+}
+
+// String returns the player id. Used when the player shows up in debug output such as a blackboard.
+func (p *Player) String() string {
+	return p.Name
+}
+
+// Position returns the player's current position
+func (p *Player) Position() vecmath.Vec3 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Pos
+}
+
+// Transform returns the player's current position and rotation
+func (p *Player) Transform() (pos vecmath.Vec3, rotationY float32) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Pos, p.RotationY
+}
+
+// SetTransform updates the player's position and rotation
+func (p *Player) SetTransform(pos vecmath.Vec3, rotationY float32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Pos, p.RotationY = pos, rotationY
+}
+
+// Violations returns how many moves from this player failed validation
+func (p *Player) Violations() uint64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.movement.violations
+}
+
+// tryAttack reports whether cooldown has passed since the player's last attack and
+// if so starts a new one at now
+func (p *Player) tryAttack(now time.Time, cooldown time.Duration) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if now.Sub(p.lastAttack) < cooldown {
+		return false
+	}
+	p.lastAttack = now
+	return true
+}
+
+// DuplicateLoginPolicy decides what happens when an id that is already online logs in again
+type DuplicateLoginPolicy int
+
+const (
+	// 기존 세션을 끊고 새 로그인으로 처음부터 시작한다
+	DuplicateLoginKickOld DuplicateLoginPolicy = iota
+	// 새 로그인을 거부한다
+	DuplicateLoginRejectNew
+	// 기존 플레이어 상태를 그대로 새 연결로 옮긴다
+	DuplicateLoginResume
+)
+
+var ErrAlreadyLoggedIn = errors.New("already logged in from another connection")
+
+// ParseDuplicateLoginPolicy converts the config name ("kick_old", "reject_new", "resume") to a policy.
+// Unknown names fall back to DuplicateLoginKickOld.
+func ParseDuplicateLoginPolicy(name string) DuplicateLoginPolicy {
+	switch name {
+	case "reject_new":
+		return DuplicateLoginRejectNew
+	case "resume":
+		return DuplicateLoginResume
+	default:
+		return DuplicateLoginKickOld
+	}
 }
 
 // PlayerManager manages a list of players
 type PlayerManager struct {
-	players map[string]*Player
-	nextID  int
+	mu             sync.RWMutex
+	players        map[string]*Player
+	nextID         int
+	pathTest       config.PathTestConfig
+	authenticator  Authenticator
+	tokens         *TokenSigner
+	duplicateLogin DuplicateLoginPolicy
+	movement       *MovementValidator
 }
 
 // NewPlayerManager creates a new PlayerManager
 func GetPlayerManager() *PlayerManager {
-	if playerManager == nil {
-		playerManager = &PlayerManager{
-			players: make(map[string]*Player),
-			nextID:  1,
-		}
-	}
+	playerManagerOnce.Do(func() {
+		cfg := config.Default()
+		tokens, _ := NewTokenSigner("", time.Duration(cfg.Auth.TokenTTL))
+		playerManager = newPlayerManager(cfg, denyAuthenticator{}, tokens)
+	})
 
 	return playerManager
 }
 
-// AddPlayer adds a new player to the manager
-func (pm *PlayerManager) AddPlayer(name string, age int, conn *net.Conn) *Player {
-	player := Player{
-		ID:        pm.nextID,
-		Name:      name,
+func newPlayerManager(cfg *config.Config, authenticator Authenticator, tokens *TokenSigner) *PlayerManager {
+	return &PlayerManager{
+		players:        make(map[string]*Player),
+		nextID:         1,
+		pathTest:       cfg.PathTest,
+		authenticator:  &TokenAuthenticator{Signer: tokens, Fallback: authenticator},
+		tokens:         tokens,
+		duplicateLogin: ParseDuplicateLoginPolicy(cfg.Auth.DuplicateLogin),
+		movement:       newMovementValidator(cfg.Movement),
+	}
+}
+
+// SetAuthenticator replaces the credential check used for logins without a token
+func (pm *PlayerManager) SetAuthenticator(authenticator Authenticator) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.authenticator = &TokenAuthenticator{Signer: pm.tokens, Fallback: authenticator}
+}
+
+// SetDuplicateLoginPolicy changes how repeated logins of an online id are handled
+func (pm *PlayerManager) SetDuplicateLoginPolicy(policy DuplicateLoginPolicy) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.duplicateLogin = policy
+}
+
+// RegisterHandlers registers the player message handlers
+func (pm *PlayerManager) RegisterHandlers(d *Dispatcher) {
+	d.Handle((*pb.GameMessage_Login)(nil), pm.handleLogin)
+	d.Handle((*pb.GameMessage_Logout)(nil), pm.handleLogout, RequireLogin())
+	d.Handle((*pb.GameMessage_PlayerPosition)(nil), pm.handlePlayerPosition, RequireLogin())
+}
+
+func (pm *PlayerManager) handleLogin(session *Session, message *pb.GameMessage) {
+	if session.PlayerId() != "" {
+		sendLoginResult(session, &pb.LoginResult{Reason: "already logged in"})
+		return
+	}
+
+	pm.mu.RLock()
+	authenticator := pm.authenticator
+	pm.mu.RUnlock()
+
+	playerId, err := authenticator.Authenticate(message.GetLogin())
+	if err != nil {
+		log.Printf("Session %d login as %q failed: %v", session.ID(), message.GetLogin().GetPlayerId(), err)
+		sendLoginResult(session, &pb.LoginResult{Reason: err.Error()})
+		return
+	}
+
+	// 이후 모든 메시지는 클라이언트가 보낸 id가 아니라 세션에 묶인 id로 처리한다
+	session.BindPlayer(playerId)
+	if _, err := pm.login(playerId, 0, session); err != nil {
+		session.BindPlayer("")
+		log.Printf("Session %d login as %q rejected: %v", session.ID(), playerId, err)
+		sendLoginResult(session, &pb.LoginResult{Reason: err.Error()})
+	}
+}
+
+func (pm *PlayerManager) handleLogout(session *Session, message *pb.GameMessage) {
+	pm.removePlayer(session.PlayerId(), session)
+	session.BindPlayer("")
+}
+
+func (pm *PlayerManager) handlePlayerPosition(session *Session, message *pb.GameMessage) {
+	position := message.GetPlayerPosition()
+	position.PlayerId = session.PlayerId()
+	GetWorld().QueueMove(position)
+}
+
+func sendLoginResult(session *Session, result *pb.LoginResult) {
+	session.Send(&pb.GameMessage{
+		Message: &pb.GameMessage_LoginResult{LoginResult: result},
+	})
+}
+
+// AddPlayer adds a new player to the manager, applying the duplicate login policy
+// if name is already online
+func (pm *PlayerManager) AddPlayer(name string, age int, session *Session) *Player {
+	player, _ := pm.login(name, age, session)
+	return player
+}
+
+// login registers playerId on session, or applies the duplicate login policy when
+// playerId is already online, then sends the login result and spawn packets
+func (pm *PlayerManager) login(playerId string, age int, session *Session) (*Player, error) {
+	pm.mu.Lock()
+	existing, exists := pm.players[playerId]
+	if exists && pm.duplicateLogin == DuplicateLoginRejectNew {
+		pm.mu.Unlock()
+		return nil, ErrAlreadyLoggedIn
+	}
+
+	var previous *Session
+	if exists {
+		previous = existing.Session()
+	}
+
+	// 기존 플레이어를 새 연결로 넘겨받는다
+	if exists && pm.duplicateLogin == DuplicateLoginResume {
+		existing.setSession(session)
+		pm.mu.Unlock()
+
+		kickSession(previous, playerId)
+		sendLoginResult(session, &pb.LoginResult{
+			Success:  true,
+			PlayerId: playerId,
+			Token:    pm.tokens.Issue(playerId),
+			Resumed:  true,
+		})
+		pm.sendWorldState(existing, GetAOIManager().Visible(playerId))
+		return existing, nil
+	}
+
+	player := &Player{
+		Name:      playerId,
 		Age:       age,
-		Conn:      conn,
-		X:         0,
-		Y:         0,
-		Z:         0,
+		session:   session,
+		movement:  pm.movement.newMoveState(time.Now()),
+		Pos:       vecmath.Vec3{},
 		RotationY: 0,
 	}
 
-	pm.players[name] = &player
+	// AOI 갱신은 등록과 같은 락 안에서 하고, 이벤트 전송은 락 밖에서 한다
+	var leaveEvents []AOIEvent
+	if exists {
+		leaveEvents = GetAOIManager().Remove(PlayerRef(playerId))
+	}
+	player.ID = pm.nextID
+	pm.players[playerId] = player
 	pm.nextID++
+	enterEvents := GetAOIManager().Add(PlayerRef(playerId), player.Pos.X, player.Pos.Z)
+	pm.mu.Unlock()
+
+	// 기존 접속은 끊고 다른 플레이어들에게는 로그아웃으로 알린다
+	if exists {
+		kickSession(previous, playerId)
+		pm.broadcastLogout(playerId, leaveEvents)
+		DeliverAOIEvents(leaveEvents)
+	}
+
+	sendLoginResult(session, &pb.LoginResult{
+		Success:  true,
+		PlayerId: playerId,
+		Token:    pm.tokens.Issue(playerId),
+	})
+
+	pm.sendWorldState(player, enterEvents)
+	return player, nil
+}
+
+// kickSession disconnects the session that previously owned playerId
+func kickSession(previous *Session, playerId string) {
+	log.Printf("Player %s logged in again, kicking session %d", playerId, previous.ID())
+	previous.BindPlayer("")
+	previous.Kick("logged in from another connection")
+}
+
+// sendWorldState sends the player its own spawn, then delivers the AOI events of
+// the login: the entities it sees, and its spawn to the players that see it
+func (pm *PlayerManager) sendWorldState(player *Player, events []AOIEvent) {
+	pos, rotationY := player.Transform()
 
 	// 내가 로그인 되었음을 나한테 알려준다.
 	myPlayerSapwn := &pb.GameMessage{
 		Message: &pb.GameMessage_SpawnMyPlayer{
 			SpawnMyPlayer: &pb.SpawnMyPlayer{
-				X:         player.X,
-				Y:         player.Y,
-				Z:         player.Z,
-				RotationY: player.RotationY,
+				X:         pos.X,
+				Y:         pos.Y,
+				Z:         pos.Z,
+				RotationY: rotationY,
 			},
 		},
 	}
 
-	pathTest := &pb.GameMessage{
-		Message: &pb.GameMessage_PathTest{
-			PathTest: &pb.PathTest{},
-		},
+	if pm.pathTest.Enabled {
+		pm.sendPathTest(player)
 	}
 
-	path, err := GetNavMeshManager().PathFinding(-230, 0, -291, 235, 0, 180)
-	if err == nil {
-		for _, path := range path.PathList {
-			pathTest.GetPathTest().Paths = append(pathTest.GetPathTest().Paths, &pb.NavV3{X: float32(path.X), Y: float32(path.Y), Z: float32(path.Z)})
-		}
-
-		response := GetNetManager().MakePacket(pathTest)
-		(*player.Conn).Write(response)
-	}
+	player.Send(myPlayerSapwn)
 
-	response := GetNetManager().MakePacket(myPlayerSapwn)
-	(*player.Conn).Write(response)
+	// 시야 안의 다른 플레이어와 몬스터 위치정보를 접속한 인원에게 보낸다.
+	DeliverAOIEvents(events)
+}
 
-	otherPlayerSpawnPacket := &pb.GameMessage{
-		Message: &pb.GameMessage_SpawnOtherPlayer{
-			SpawnOtherPlayer: &pb.SpawnOtherPlayer{
-				PlayerId:  name,
-				X:         player.X,
-				Y:         player.Y,
-				Z:         player.Z,
-				RotationY: player.RotationY,
-			},
+// sendPathTest sends the configured debug path to the player
+func (pm *PlayerManager) sendPathTest(player *Player) {
+	pathTest := &pb.GameMessage{
+		Message: &pb.GameMessage_PathTest{
+			PathTest: &pb.PathTest{},
 		},
 	}
 
-	// 이 코드를 들어온 유저를 제외한 플레이어들에게 스폰시켜달라고 한다.
-	for _, p := range pm.players {
-		if p.Name == name {
-			continue
-		}
-
-		response = GetNetManager().MakePacket(otherPlayerSpawnPacket)
-
-		(*p.Conn).Write(response)
+	from, to := pm.pathTest.From, pm.pathTest.To
+	path, err := GetNavMeshManager().PathFinding(
+		vecmath.New(float32(from[0]), float32(from[1]), float32(from[2])),
+		vecmath.New(float32(to[0]), float32(to[1]), float32(to[2])))
+	if err != nil {
+		return
 	}
 
-	// 다른 플레이어의 위치정보를 접속한 인원에게 보낸다.
-	for _, p := range pm.players {
-		if p.Name == name {
-			continue
-		}
-
-		otherPlayerSpawnPacket := &pb.GameMessage{
-			Message: &pb.GameMessage_SpawnOtherPlayer{
-				SpawnOtherPlayer: &pb.SpawnOtherPlayer{
-					PlayerId:  p.Name,
-					X:         p.X,
-					Y:         p.Y,
-					Z:         p.Z,
-					RotationY: player.RotationY,
-				},
-			},
-		}
-
-		response = GetNetManager().MakePacket(otherPlayerSpawnPacket)
-
-		(*player.Conn).Write(response)
+	// 클라이언트에는 게임 좌표로 보낸다
+	for _, point := range path {
+		pathTest.GetPathTest().Paths = append(pathTest.GetPathTest().Paths, &pb.NavV3{X: point.X, Y: point.Y, Z: point.Z})
 	}
 
-	return &player
+	player.Send(pathTest)
 }
 
-func (pm *PlayerManager) MovePlayer(p *pb.GameMessage_PlayerPosition) {
-
-	pm.players[p.PlayerPosition.PlayerId].X = p.PlayerPosition.X
-	pm.players[p.PlayerPosition.PlayerId].Y = p.PlayerPosition.Y
-	pm.players[p.PlayerPosition.PlayerId].Z = p.PlayerPosition.Z
-	pm.players[p.PlayerPosition.PlayerId].RotationY = p.PlayerPosition.RotationY
-
-	response, err := proto.Marshal(&pb.GameMessage{
-		Message: p,
-	})
-
+// MovePlayer validates and applies a position update and moves the player in the AOI grid.
+// It reports whether the position was applied, possibly clamped into position, and returns
+// the resulting enter/leave events. Other players are notified by the world loop at the end of the tick.
+func (pm *PlayerManager) MovePlayer(position *pb.PlayerPosition) ([]AOIEvent, bool) {
+	player, err := pm.GetPlayer(position.PlayerId)
 	if err != nil {
-		log.Printf("Failed to marshal response: %v", err)
-		return
+		return nil, false
 	}
 
-	for _, player := range pm.players {
-		if player.Name == p.PlayerPosition.PlayerId {
-			continue
-		}
+	// 보정 패킷 전송이 block 정책에서 기다릴 수 있으므로 검증은 락 밖에서 한다
+	if !pm.movement.Move(player, position, time.Now()) {
+		return nil, false
+	}
 
-		lengthBuf := make([]byte, 4)
-		binary.LittleEndian.PutUint32(lengthBuf, uint32(len(response)))
-		lengthBuf = append(lengthBuf, response...)
-		(*player.Conn).Write(lengthBuf)
+	// 그 사이 로그아웃했거나 다른 접속으로 바뀐 플레이어는 AOI에 다시 넣지 않는다
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	if pm.players[position.PlayerId] != player {
+		return nil, false
 	}
+	return GetAOIManager().Move(PlayerRef(position.PlayerId), position.X, position.Z), true
 }
 
 // GetPlayer retrieves a player by ID
 func (pm *PlayerManager) GetPlayer(id string) (*Player, error) {
+	pm.mu.RLock()
 	player, exists := pm.players[id]
+	pm.mu.RUnlock()
 	if !exists {
 		return nil, errors.New("player not found")
 	}
@@ -178,11 +395,43 @@ func (pm *PlayerManager) GetPlayer(id string) (*Player, error) {
 
 // RemovePlayer removes a player by ID
 func (pm *PlayerManager) RemovePlayer(id string) error {
-	if _, exists := pm.players[id]; !exists {
+	return pm.removePlayer(id, nil)
+}
+
+// HandleDisconnect logs out the player bound to a closed session, notifying everyone else.
+// Does nothing if the session never logged in.
+func (pm *PlayerManager) HandleDisconnect(session *Session) {
+	playerId := session.PlayerId()
+	if playerId == "" {
+		return
+	}
+	session.BindPlayer("")
+
+	if err := pm.removePlayer(playerId, session); err == nil {
+		log.Printf("Player %s disconnected (session %d)", playerId, session.ID())
+	}
+}
+
+// removePlayer removes the player and broadcasts the logout. If session is not nil the
+// player is only removed while still owned by that session.
+func (pm *PlayerManager) removePlayer(id string, session *Session) error {
+	pm.mu.Lock()
+	player, exists := pm.players[id]
+	if !exists || (session != nil && player.Session() != session) {
+		pm.mu.Unlock()
 		return errors.New("player not found")
 	}
 	delete(pm.players, id)
+	events := GetAOIManager().Remove(PlayerRef(id))
+	pm.mu.Unlock()
+
+	pm.broadcastLogout(id, events)
+	DeliverAOIEvents(events)
+	return nil
+}
 
+// broadcastLogout tells the players that saw id, the observers of its AOI leave events, that it has left
+func (pm *PlayerManager) broadcastLogout(id string, events []AOIEvent) {
 	logoutPacket := &pb.GameMessage{
 		Message: &pb.GameMessage_Logout{
 			Logout: &pb.LogoutMessage{
@@ -192,19 +441,35 @@ func (pm *PlayerManager) RemovePlayer(id string) error {
 	}
 
 	response := GetNetManager().MakePacket(logoutPacket)
-
-	// 이 코드를 들어온 유저를 제외한 플레이어들에게 스폰시켜달라고 한다.
-	for _, p := range pm.players {
-		(*p.Conn).Write(response)
+	if response == nil {
+		return
 	}
 
-	return nil
+	// 시야 밖의 플레이어는 떠난 플레이어를 모르므로 알리지 않는다
+	for _, event := range events {
+		if event.Entity != PlayerRef(id) {
+			continue
+		}
+		if p, err := pm.GetPlayer(event.Observer); err == nil {
+			p.SendPacket(response)
+		}
+	}
 }
 
 // ListPlayers returns all players in the manager
 func (pm *PlayerManager) ListPlayers() []*Player {
-	playerList := []*Player{}
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.listPlayersExcept("")
+}
+
+// listPlayersExcept copies the player list without the given id. pm.mu must be held.
+func (pm *PlayerManager) listPlayersExcept(id string) []*Player {
+	playerList := make([]*Player, 0, len(pm.players))
 	for _, player := range pm.players {
+		if player.Name == id {
+			continue
+		}
 		playerList = append(playerList, player)
 	}
 	return playerList
@@ -0,0 +1,324 @@
+package manager
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"testServer/vecmath"
+
+	pb "testServer/Messages"
+)
+
+// trustingAuthenticator accepts whatever player id the client sends
+type trustingAuthenticator struct{}
+
+func (trustingAuthenticator) Authenticate(login *pb.LoginMessage) (string, error) {
+	return login.PlayerId, nil
+}
+
+func TestMain(m *testing.M) {
+	// 이동 검증이 통과하도록 원점 주변에 평평한 네비메시를 깔아 둔다
+	navMeshManagerOnce.Do(func() {
+		navMeshManager = newTestNavMesh(1000)
+	})
+	GetPlayerManager().SetAuthenticator(trustingAuthenticator{})
+	os.Exit(m.Run())
+}
+
+// newTestSession returns a started session whose client side is drained in the background
+func newTestSession(t *testing.T) *Session {
+	t.Helper()
+	server, client := net.Pipe()
+	go io.Copy(io.Discard, client)
+
+	session := NewSession(server, DefaultSendQueueSize, BackpressureDrop)
+	session.Start()
+	t.Cleanup(func() {
+		session.Close()
+		client.Close()
+	})
+	return session
+}
+
+// newTestClient returns a started session and a channel receiving every message sent to it
+func newTestClient(t *testing.T) (*Session, <-chan *pb.GameMessage) {
+	t.Helper()
+	server, client := net.Pipe()
+	messages := make(chan *pb.GameMessage, 64)
+	go func() {
+		defer close(messages)
+		reader := NewPacketReader(client, 0)
+		for {
+			message, err := reader.ReadMessage()
+			if err != nil {
+				return
+			}
+			messages <- message
+		}
+	}()
+
+	session := NewSession(server, DefaultSendQueueSize, BackpressureDrop)
+	session.Start()
+	t.Cleanup(func() {
+		session.Close()
+		client.Close()
+	})
+	return session, messages
+}
+
+func TestConcurrentLoginMoveChatLogout(t *testing.T) {
+	const (
+		clients = 32
+		moves   = 50
+		chats   = 10
+	)
+
+	d := GetDispatcher()
+	var wg sync.WaitGroup
+
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			session := newTestSession(t)
+			playerId := fmt.Sprintf("player-%d", i)
+
+			d.Dispatch(session, &pb.GameMessage{
+				Message: &pb.GameMessage_Login{Login: &pb.LoginMessage{PlayerId: playerId}},
+			})
+			for m := 0; m < moves; m++ {
+				d.Dispatch(session, &pb.GameMessage{
+					Message: &pb.GameMessage_PlayerPosition{PlayerPosition: &pb.PlayerPosition{
+						PlayerId: playerId,
+						X:        float32(m),
+						Z:        float32(i),
+					}},
+				})
+				if m < chats {
+					d.Dispatch(session, &pb.GameMessage{
+						Message: &pb.GameMessage_Chat{Chat: &pb.ChatMessage{Sender: playerId, Content: "hi"}},
+					})
+				}
+			}
+			d.Dispatch(session, &pb.GameMessage{
+				Message: &pb.GameMessage_Logout{Logout: &pb.LogoutMessage{PlayerId: playerId}},
+			})
+		}(i)
+	}
+
+	// 동시에 몬스터를 추가하고 월드 틱을 돌린다
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			GetMonsterManager().AddMonster(MonsterDefinition{})
+			GetWorld().Tick(50 * time.Millisecond)
+			for _, p := range GetPlayerManager().ListPlayers() {
+				p.Position()
+			}
+			GetMonsterManager().DebugInfo()
+		}
+	}()
+
+	wg.Wait()
+
+	if players := GetPlayerManager().ListPlayers(); len(players) != 0 {
+		t.Fatalf("expected every player to be logged out, %d left", len(players))
+	}
+	if unknown := d.UnknownCount(); unknown != 0 {
+		t.Fatalf("expected no unknown messages, got %d", unknown)
+	}
+}
+
+func TestDisconnectLogsOutPlayer(t *testing.T) {
+	d := GetDispatcher()
+	leaving := newTestSession(t)
+	staying, messages := newTestClient(t)
+	far, farMessages := newTestClient(t)
+
+	d.Dispatch(staying, &pb.GameMessage{
+		Message: &pb.GameMessage_Login{Login: &pb.LoginMessage{PlayerId: "staying"}},
+	})
+	d.Dispatch(leaving, &pb.GameMessage{
+		Message: &pb.GameMessage_Login{Login: &pb.LoginMessage{PlayerId: "leaving"}},
+	})
+	d.Dispatch(far, &pb.GameMessage{
+		Message: &pb.GameMessage_Login{Login: &pb.LoginMessage{PlayerId: "far"}},
+	})
+	defer GetPlayerManager().RemovePlayer("far")
+
+	// 시야 밖으로 옮겨 둔 플레이어는 로그아웃을 받지 않아야 한다
+	farPlayer, err := GetPlayerManager().GetPlayer("far")
+	if err != nil {
+		t.Fatal(err)
+	}
+	farPlayer.SetTransform(vecmath.New(500, 0, 500), 0)
+	GetAOIManager().Move(PlayerRef("far"), 500, 500)
+
+	// 소켓이 끊어진 상황
+	leaving.Close()
+	GetPlayerManager().HandleDisconnect(leaving)
+	defer GetPlayerManager().RemovePlayer("staying")
+
+	if _, err := GetPlayerManager().GetPlayer("leaving"); err == nil {
+		t.Fatal("disconnected player is still registered")
+	}
+	if leaving.PlayerId() != "" {
+		t.Fatalf("session still bound to %q", leaving.PlayerId())
+	}
+
+	timeout := time.After(time.Second)
+wait:
+	for {
+		select {
+		case message := <-messages:
+			if message.GetLogout().GetPlayerId() == "leaving" {
+				break wait
+			}
+		case <-timeout:
+			t.Fatal("remaining player never received the logout")
+		}
+	}
+
+	quiet := time.After(50 * time.Millisecond)
+	for {
+		select {
+		case message := <-farMessages:
+			if message.GetLogout().GetPlayerId() == "leaving" {
+				t.Fatal("player out of sight received the logout")
+			}
+		case <-quiet:
+			return
+		}
+	}
+}
+
+func TestDuplicateLoginPolicies(t *testing.T) {
+	pm := GetPlayerManager()
+	d := GetDispatcher()
+	defer pm.SetDuplicateLoginPolicy(DuplicateLoginKickOld)
+
+	tests := []struct {
+		name        string
+		policy      DuplicateLoginPolicy
+		wantNewOwns bool
+		wantKick    bool
+	}{
+		{"kick old", DuplicateLoginKickOld, true, true},
+		{"reject new", DuplicateLoginRejectNew, false, false},
+		{"resume", DuplicateLoginResume, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm.SetDuplicateLoginPolicy(tt.policy)
+			first, firstMessages := newTestClient(t)
+			second := newTestSession(t)
+			login := &pb.GameMessage{
+				Message: &pb.GameMessage_Login{Login: &pb.LoginMessage{PlayerId: "duplicate"}},
+			}
+			defer pm.RemovePlayer("duplicate")
+
+			d.Dispatch(first, login)
+			pm.MovePlayer(&pb.PlayerPosition{PlayerId: "duplicate", X: 7})
+			d.Dispatch(second, login)
+
+			player, err := pm.GetPlayer("duplicate")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if owns := player.Session() == second; owns != tt.wantNewOwns {
+				t.Fatalf("second session owns player = %v, want %v", owns, tt.wantNewOwns)
+			}
+			if x := player.Position().X; tt.policy == DuplicateLoginResume && x != 7 {
+				t.Fatalf("resumed player lost its position, x = %v", x)
+			}
+
+			kicked := false
+			timeout := time.After(500 * time.Millisecond)
+		read:
+			for {
+				select {
+				case message, ok := <-firstMessages:
+					if !ok {
+						break read
+					}
+					if message.GetKick() != nil {
+						kicked = true
+						break read
+					}
+				case <-timeout:
+					break read
+				}
+			}
+			if kicked != tt.wantKick {
+				t.Fatalf("first session kicked = %v, want %v", kicked, tt.wantKick)
+			}
+		})
+	}
+}
+
+// 메시지에 다른 플레이어의 id를 넣어도 세션에 묶인 플레이어로 처리되어야 한다
+func TestMessagesUseSessionIdentity(t *testing.T) {
+	pm := GetPlayerManager()
+	d := GetDispatcher()
+	alice := newTestSession(t)
+	bob, bobMessages := newTestClient(t)
+	for session, playerId := range map[*Session]string{alice: "alice", bob: "bob"} {
+		d.Dispatch(session, &pb.GameMessage{
+			Message: &pb.GameMessage_Login{Login: &pb.LoginMessage{PlayerId: playerId}},
+		})
+	}
+	defer pm.RemovePlayer("alice")
+	defer pm.RemovePlayer("bob")
+
+	bobPlayer, err := pm.GetPlayer("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alicePlayer, err := pm.GetPlayer("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bobStart := bobPlayer.Position()
+	destination := alicePlayer.Position().Add(vecmath.New(0.5, 0, 0))
+
+	d.Dispatch(alice, &pb.GameMessage{
+		Message: &pb.GameMessage_PlayerPosition{PlayerPosition: &pb.PlayerPosition{
+			PlayerId: "bob",
+			X:        destination.X,
+			Y:        destination.Y,
+			Z:        destination.Z,
+		}},
+	})
+	GetWorld().Tick(50 * time.Millisecond)
+	if got := bobPlayer.Position(); got != bobStart {
+		t.Fatalf("bob moved to %v by alice's message", got)
+	}
+	if got := alicePlayer.Position(); got != destination {
+		t.Fatalf("alice at %v, want %v", got, destination)
+	}
+
+	d.Dispatch(alice, &pb.GameMessage{
+		Message: &pb.GameMessage_Chat{Chat: &pb.ChatMessage{Sender: "bob", Content: "spoofed"}},
+	})
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case message := <-bobMessages:
+			if chat := message.GetChat(); chat != nil {
+				if chat.Sender != "alice" {
+					t.Fatalf("chat sent as %q, want alice", chat.Sender)
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatal("bob never received the chat")
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package manager
+
+import (
+	"errors"
+	"log"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "testServer/Messages"
+)
+
+// BackpressurePolicy decides what Send does when a session's outbound queue is full
+type BackpressurePolicy int
+
+const (
+	// 큐가 가득 차면 해당 패킷을 버린다
+	BackpressureDrop BackpressurePolicy = iota
+	// 큐가 가득 차면 느린 클라이언트의 연결을 끊는다
+	BackpressureKick
+	// 큐에 자리가 날 때까지 보내는 쪽이 기다린다. 그래서 매니저 락을 잡은 채로 보내면 안 된다.
+	BackpressureBlock
+)
+
+const DefaultSendQueueSize = 256
+
+// ParseBackpressurePolicy converts the config name ("drop", "kick", "block") to a policy.
+// Unknown names fall back to BackpressureKick.
+func ParseBackpressurePolicy(name string) BackpressurePolicy {
+	switch name {
+	case "drop":
+		return BackpressureDrop
+	case "block":
+		return BackpressureBlock
+	default:
+		return BackpressureKick
+	}
+}
+
+// 쓰기 한 번에 모아서 보낼 최대 패킷 수
+const maxWriteBatch = 64
+
+// 킥 메시지를 보내고 연결을 닫기까지 기다리는 최대 시간
+const kickFlushTimeout = time.Second
+
+var (
+	ErrSessionClosed = errors.New("session closed")
+	ErrSendQueueFull = errors.New("send queue full")
+)
+
+var lastSessionID atomic.Uint64
+
+// Session wraps a client connection with its own outbound write queue.
+// All writes to the connection happen on the session's writer goroutine.
+type Session struct {
+	id     uint64
+	conn   net.Conn
+	send   chan []byte
+	policy BackpressurePolicy
+
+	// 쓰기가 이 시간 안에 끝나지 않으면 죽은 연결로 보고 닫는다 (0이면 제한 없음)
+	writeTimeout time.Duration
+	// 이 간격마다 Ping을 보낸다 (0이면 보내지 않음)
+	pingInterval time.Duration
+
+	pingMu       sync.Mutex
+	pingSequence int64
+	pingSentAt   time.Time
+	rtt          time.Duration
+	smoothedRTT  time.Duration
+
+	mu       sync.RWMutex
+	playerId string
+
+	closeOnce sync.Once
+	closed    chan struct{}
+	flushOnce sync.Once
+	flushing  chan struct{}
+	done      chan struct{}
+	dropped   atomic.Uint64
+}
+
+// NewSession creates a session for conn. Call Start to begin writing.
+func NewSession(conn net.Conn, queueSize int, policy BackpressurePolicy) *Session {
+	if queueSize <= 0 {
+		queueSize = DefaultSendQueueSize
+	}
+	return &Session{
+		id:       lastSessionID.Add(1),
+		conn:     conn,
+		send:     make(chan []byte, queueSize),
+		policy:   policy,
+		closed:   make(chan struct{}),
+		flushing: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// ID returns the server-unique session id
+func (s *Session) ID() uint64 {
+	return s.id
+}
+
+// RemoteAddr returns the client's address
+func (s *Session) RemoteAddr() net.Addr {
+	return s.conn.RemoteAddr()
+}
+
+// PlayerId returns the id of the player logged in on this session, or "" before login
+func (s *Session) PlayerId() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.playerId
+}
+
+// BindPlayer associates the session with a logged-in player id
+func (s *Session) BindPlayer(playerId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.playerId = playerId
+}
+
+// Start launches the writer goroutine, and the heartbeat if a ping interval is set
+func (s *Session) Start() {
+	go s.writeLoop()
+	if s.pingInterval > 0 {
+		go s.heartbeatLoop()
+	}
+}
+
+// RTT returns the last measured round-trip time and a smoothed average.
+// Both are zero until the first Pong arrives.
+func (s *Session) RTT() (last time.Duration, smoothed time.Duration) {
+	s.pingMu.Lock()
+	defer s.pingMu.Unlock()
+	return s.rtt, s.smoothedRTT
+}
+
+// SendPing sends a Ping and remembers when it left, to time the matching Pong
+func (s *Session) SendPing() error {
+	s.pingMu.Lock()
+	s.pingSequence++
+	s.pingSentAt = time.Now()
+	ping := &pb.Ping{Sequence: s.pingSequence, SentAt: s.pingSentAt.UnixMilli()}
+	s.pingMu.Unlock()
+
+	return s.Send(&pb.GameMessage{
+		Message: &pb.GameMessage_Ping{Ping: ping},
+	})
+}
+
+// recordPong updates the RTT if pong answers the most recent Ping
+func (s *Session) recordPong(pong *pb.Pong) {
+	s.pingMu.Lock()
+	defer s.pingMu.Unlock()
+
+	// 이전 Ping에 대한 늦은 응답이나 위조된 시퀀스는 무시한다
+	if pong.Sequence != s.pingSequence || s.pingSentAt.IsZero() {
+		return
+	}
+
+	s.rtt = time.Since(s.pingSentAt)
+	s.pingSentAt = time.Time{}
+	if s.smoothedRTT == 0 {
+		s.smoothedRTT = s.rtt
+	} else {
+		// TCP와 같은 방식의 지수 이동 평균 (1/8)
+		s.smoothedRTT += (s.rtt - s.smoothedRTT) / 8
+	}
+}
+
+func (s *Session) heartbeatLoop() {
+	ticker := time.NewTicker(s.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.SendPing()
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// Send marshals msg and queues it for the client
+func (s *Session) Send(msg *pb.GameMessage) error {
+	packet := GetNetManager().MakePacket(msg)
+	if packet == nil {
+		return errors.New("failed to make packet")
+	}
+	return s.SendPacket(packet)
+}
+
+// SendPacket queues an already framed packet. The packet must not be modified
+// afterwards, so one packet can be shared by many sessions when broadcasting.
+func (s *Session) SendPacket(packet []byte) error {
+	select {
+	case <-s.closed:
+		return ErrSessionClosed
+	default:
+	}
+
+	if s.policy == BackpressureBlock {
+		select {
+		case s.send <- packet:
+			return nil
+		case <-s.closed:
+			return ErrSessionClosed
+		}
+	}
+
+	select {
+	case s.send <- packet:
+		return nil
+	default:
+	}
+
+	s.dropped.Add(1)
+	if s.policy == BackpressureKick {
+		log.Printf("Session %d send queue full, disconnecting %v", s.id, s.RemoteAddr())
+		s.Close()
+	}
+	return ErrSendQueueFull
+}
+
+// Kick tells the client why it is being disconnected, then flushes and closes the session
+func (s *Session) Kick(reason string) {
+	s.Send(&pb.GameMessage{
+		Message: &pb.GameMessage_Kick{Kick: &pb.KickMessage{Reason: reason}},
+	})
+	go s.Shutdown(kickFlushTimeout)
+}
+
+// Dropped returns how many packets were discarded because the queue was full
+func (s *Session) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close closes the connection and stops the writer. It is safe to call more than once.
+func (s *Session) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		s.conn.Close()
+	})
+}
+
+// Shutdown writes out everything already queued and then closes the session.
+// Pending writes are abandoned once timeout has passed.
+func (s *Session) Shutdown(timeout time.Duration) {
+	s.conn.SetWriteDeadline(time.Now().Add(timeout))
+	s.flushOnce.Do(func() {
+		close(s.flushing)
+	})
+
+	select {
+	case <-s.done:
+	case <-time.After(timeout):
+	}
+	s.Close()
+}
+
+// Closed is closed once the session has been closed
+func (s *Session) Closed() <-chan struct{} {
+	return s.closed
+}
+
+// Done is closed once the writer goroutine has exited
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Session) writeLoop() {
+	defer close(s.done)
+	batch := make(net.Buffers, 0, maxWriteBatch)
+	for {
+		select {
+		case packet := <-s.send:
+			// 큐에 쌓인 패킷을 모아 한 번의 시스템 콜로 보낸다
+			batch = append(batch[:0], packet)
+		drain:
+			for len(batch) < maxWriteBatch {
+				select {
+				case packet := <-s.send:
+					batch = append(batch, packet)
+				default:
+					break drain
+				}
+			}
+
+			if s.writeTimeout > 0 {
+				s.conn.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+			}
+			buffers := batch
+			if _, err := buffers.WriteTo(s.conn); err != nil {
+				log.Printf("Session %d write failed: %v", s.id, err)
+				s.Close()
+				return
+			}
+		case <-s.flushing:
+			// 남은 패킷을 모두 보내고 종료한다
+			batch = batch[:0]
+		flush:
+			for {
+				select {
+				case packet := <-s.send:
+					batch = append(batch, packet)
+				default:
+					break flush
+				}
+			}
+			buffers := batch
+			if _, err := buffers.WriteTo(s.conn); err != nil {
+				log.Printf("Session %d flush failed: %v", s.id, err)
+			}
+			s.Close()
+			return
+		case <-s.closed:
+			return
+		}
+	}
+}
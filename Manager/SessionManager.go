@@ -0,0 +1,79 @@
+package manager
+
+import (
+	"sync"
+	"time"
+
+	pb "testServer/Messages"
+)
+
+var (
+	sessionManager     *SessionManager
+	sessionManagerOnce sync.Once
+)
+
+// SessionManager keeps track of every open client session
+type SessionManager struct {
+	mu       sync.RWMutex
+	sessions map[uint64]*Session
+}
+
+// GetSessionManager returns the SessionManager
+func GetSessionManager() *SessionManager {
+	sessionManagerOnce.Do(func() {
+		sessionManager = &SessionManager{
+			sessions: make(map[uint64]*Session),
+		}
+	})
+
+	return sessionManager
+}
+
+// AddSession starts tracking a session
+func (sm *SessionManager) AddSession(session *Session) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.sessions[session.ID()] = session
+}
+
+// RemoveSession stops tracking a session
+func (sm *SessionManager) RemoveSession(session *Session) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	delete(sm.sessions, session.ID())
+}
+
+// ListSessions returns all open sessions
+func (sm *SessionManager) ListSessions() []*Session {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	sessionList := make([]*Session, 0, len(sm.sessions))
+	for _, session := range sm.sessions {
+		sessionList = append(sessionList, session)
+	}
+	return sessionList
+}
+
+// Broadcast sends msg to every open session
+func (sm *SessionManager) Broadcast(msg *pb.GameMessage) {
+	response := GetNetManager().MakePacket(msg)
+	if response == nil {
+		return
+	}
+	for _, session := range sm.ListSessions() {
+		session.SendPacket(response)
+	}
+}
+
+// ShutdownAll flushes and closes every session in parallel, waiting at most timeout
+func (sm *SessionManager) ShutdownAll(timeout time.Duration) {
+	var wg sync.WaitGroup
+	for _, session := range sm.ListSessions() {
+		wg.Add(1)
+		go func(session *Session) {
+			defer wg.Done()
+			session.Shutdown(timeout)
+		}(session)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,252 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	pb "testServer/Messages"
+)
+
+// newStalledSession returns a started session with a one-packet queue whose client never reads
+func newStalledSession(t *testing.T, policy BackpressurePolicy) *Session {
+	t.Helper()
+	server, client := net.Pipe()
+	session := NewSession(server, 1, policy)
+	session.Start()
+	t.Cleanup(func() {
+		session.Close()
+		client.Close()
+	})
+	return session
+}
+
+// fillQueue sends until the session reports a full queue. The writer holds one packet
+// stuck in the pipe and the queue holds one more, so it takes at most three sends.
+func fillQueue(t *testing.T, session *Session) {
+	t.Helper()
+	for i := 0; i < 3; i++ {
+		if err := session.Send(chatMessage(fmt.Sprint(i))); err != nil {
+			if !errors.Is(err, ErrSendQueueFull) {
+				t.Fatalf("send %d: %v, want %v", i, err, ErrSendQueueFull)
+			}
+			return
+		}
+		// 쓰기 고루틴이 첫 패킷을 가져갈 시간을 준다
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("queue never filled up")
+}
+
+func TestBackpressureDropKeepsSession(t *testing.T) {
+	session := newStalledSession(t, BackpressureDrop)
+	fillQueue(t, session)
+
+	if err := session.Send(chatMessage("more")); !errors.Is(err, ErrSendQueueFull) {
+		t.Fatalf("send to full queue: %v, want %v", err, ErrSendQueueFull)
+	}
+	if dropped := session.Dropped(); dropped != 2 {
+		t.Fatalf("dropped = %d, want 2", dropped)
+	}
+	select {
+	case <-session.Closed():
+		t.Fatal("drop policy closed the session")
+	default:
+	}
+}
+
+func TestBackpressureKickClosesSession(t *testing.T) {
+	session := newStalledSession(t, BackpressureKick)
+	fillQueue(t, session)
+
+	select {
+	case <-session.Closed():
+	case <-time.After(time.Second):
+		t.Fatal("kick policy left the session open")
+	}
+	if err := session.Send(chatMessage("after")); !errors.Is(err, ErrSessionClosed) {
+		t.Fatalf("send after kick: %v, want %v", err, ErrSessionClosed)
+	}
+}
+
+func TestBackpressureBlockWaitsForSpace(t *testing.T) {
+	session := newStalledSession(t, BackpressureBlock)
+
+	result := make(chan error, 1)
+	go func() {
+		for i := 0; ; i++ {
+			if err := session.Send(chatMessage(fmt.Sprint(i))); err != nil {
+				result <- err
+				return
+			}
+		}
+	}()
+
+	select {
+	case err := <-result:
+		t.Fatalf("blocking send returned %v before the session closed", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	session.Close()
+	select {
+	case err := <-result:
+		if !errors.Is(err, ErrSessionClosed) {
+			t.Fatalf("blocked send: %v, want %v", err, ErrSessionClosed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked send didn't return after Close")
+	}
+}
+
+// readAll reads messages until the connection closes
+func readAll(t *testing.T, conn net.Conn) []*pb.GameMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	reader := NewPacketReader(conn, 0)
+	var messages []*pb.GameMessage
+	for {
+		message, err := reader.ReadMessage()
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrClosedPipe) {
+				t.Fatalf("read: %v", err)
+			}
+			return messages
+		}
+		messages = append(messages, message)
+	}
+}
+
+func TestShutdownFlushesQueuedPackets(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	session := NewSession(server, 8, BackpressureDrop)
+	for i := 0; i < 5; i++ {
+		if err := session.Send(chatMessage(fmt.Sprint(i))); err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+	session.Start()
+	go session.Shutdown(time.Second)
+
+	messages := readAll(t, client)
+	if len(messages) != 5 {
+		t.Fatalf("received %d messages, want 5", len(messages))
+	}
+	for i, message := range messages {
+		if content := message.GetChat().GetContent(); content != fmt.Sprint(i) {
+			t.Fatalf("message %d = %q, want %q", i, content, fmt.Sprint(i))
+		}
+	}
+	<-session.Done()
+}
+
+func TestKickSendsReasonLast(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	session := NewSession(server, 8, BackpressureDrop)
+	session.Start()
+	session.Send(chatMessage("before"))
+	session.Kick("bye")
+
+	messages := readAll(t, client)
+	if len(messages) != 2 {
+		t.Fatalf("received %d messages, want 2", len(messages))
+	}
+	if reason := messages[1].GetKick().GetReason(); reason != "bye" {
+		t.Fatalf("last message = %v, want kick with reason %q", messages[1], "bye")
+	}
+}
+
+func TestShutdownGivesUpOnStalledClient(t *testing.T) {
+	session := newStalledSession(t, BackpressureDrop)
+	session.Send(chatMessage("stuck"))
+
+	start := time.Now()
+	session.Shutdown(50 * time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Shutdown took %v with a 50ms timeout", elapsed)
+	}
+	select {
+	case <-session.Closed():
+	default:
+		t.Fatal("session still open after Shutdown")
+	}
+}
+
+func TestRecordPongMeasuresRTT(t *testing.T) {
+	// 쓰기 고루틴 없이 큐에만 쌓아 둔다
+	server, client := net.Pipe()
+	defer client.Close()
+	session := NewSession(server, 8, BackpressureDrop)
+
+	session.SendPing()
+	time.Sleep(20 * time.Millisecond)
+	session.recordPong(&pb.Pong{Sequence: 1})
+	first, smoothed := session.RTT()
+	if first < 20*time.Millisecond {
+		t.Fatalf("rtt = %v, want at least 20ms", first)
+	}
+	if smoothed != first {
+		t.Fatalf("smoothed = %v after the first pong, want %v", smoothed, first)
+	}
+
+	session.SendPing()
+	session.recordPong(&pb.Pong{Sequence: 2})
+	second, smoothed := session.RTT()
+	if want := first + (second-first)/8; smoothed != want {
+		t.Fatalf("smoothed = %v, want %v", smoothed, want)
+	}
+}
+
+func TestRecordPongIgnoresStaleSequences(t *testing.T) {
+	// 쓰기 고루틴 없이 큐에만 쌓아 둔다
+	server, client := net.Pipe()
+	defer client.Close()
+	session := NewSession(server, 8, BackpressureDrop)
+
+	session.SendPing()
+	session.SendPing()
+	session.recordPong(&pb.Pong{Sequence: 1})
+	session.recordPong(&pb.Pong{Sequence: 99})
+	if last, _ := session.RTT(); last != 0 {
+		t.Fatalf("rtt = %v after stale and unknown pongs, want 0", last)
+	}
+
+	session.recordPong(&pb.Pong{Sequence: 2})
+	answered, _ := session.RTT()
+	if answered == 0 {
+		t.Fatal("pong for the latest ping wasn't recorded")
+	}
+
+	// 같은 Pong이 다시 오면 이미 답을 받은 Ping이므로 무시한다
+	time.Sleep(5 * time.Millisecond)
+	session.recordPong(&pb.Pong{Sequence: 2})
+	if last, _ := session.RTT(); last != answered {
+		t.Fatalf("duplicate pong changed rtt from %v to %v", answered, last)
+	}
+}
+
+func TestHeartbeatSendsPings(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	session := NewSession(server, 8, BackpressureDrop)
+	session.pingInterval = 10 * time.Millisecond
+	session.Start()
+	defer session.Close()
+
+	client.SetReadDeadline(time.Now().Add(time.Second))
+	reader := NewPacketReader(client, 0)
+	for want := int64(1); want <= 3; want++ {
+		message, err := reader.ReadMessage()
+		if err != nil {
+			t.Fatalf("waiting for ping %d: %v", want, err)
+		}
+		if sequence := message.GetPing().GetSequence(); sequence != want {
+			t.Fatalf("got %v, want ping %d", message, want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package manager
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// ShutdownHook persists state before the server exits
+type ShutdownHook func(ctx context.Context) error
+
+type namedShutdownHook struct {
+	name string
+	hook ShutdownHook
+}
+
+var (
+	shutdownHooksMu sync.Mutex
+	shutdownHooks   []namedShutdownHook
+)
+
+// RegisterShutdownHook adds a hook that runs during graceful shutdown, in registration order.
+// Nothing registers one yet: players, monsters and the accounts file are only held in memory
+// or read at startup, so there is no state to save.
+func RegisterShutdownHook(name string, hook ShutdownHook) {
+	shutdownHooksMu.Lock()
+	defer shutdownHooksMu.Unlock()
+	shutdownHooks = append(shutdownHooks, namedShutdownHook{name: name, hook: hook})
+}
+
+// RunShutdownHooks runs every registered hook. A failing hook is logged and does not stop the rest.
+func RunShutdownHooks(ctx context.Context) {
+	shutdownHooksMu.Lock()
+	hooks := append([]namedShutdownHook(nil), shutdownHooks...)
+	shutdownHooksMu.Unlock()
+
+	for _, h := range hooks {
+		if err := ctx.Err(); err != nil {
+			log.Printf("Shutdown deadline reached, skipping hook %s", h.name)
+			continue
+		}
+		if err := h.hook(ctx); err != nil {
+			log.Printf("Shutdown hook %s failed: %v", h.name, err)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package manager
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"testServer/behavior"
+
+	pb "testServer/Messages"
+)
+
+// 세션별로 보관하는 스냅샷 수. ack가 이보다 늦으면 전체 상태를 다시 보낸다.
+const snapshotHistorySize = 32
+
+var (
+	snapshotManager     *SnapshotManager
+	snapshotManagerOnce sync.Once
+)
+
+// SnapshotStats counts the snapshots sent since startup
+type SnapshotStats struct {
+	Sent  uint64 `json:"sent"`
+	Full  uint64 `json:"full"`
+	Bytes uint64 `json:"bytes"`
+}
+
+type entityState struct {
+	X, Y, Z, RotationY float32
+	HP                 int
+	Action             behavior.Action
+	TargetId           string
+}
+
+type snapshot struct {
+	sequence uint32
+	entities map[EntityRef]entityState
+}
+
+// snapshotClient tracks what one session has been sent and what it has acknowledged
+type snapshotClient struct {
+	mu       sync.Mutex
+	sequence uint32
+	history  []*snapshot
+	acked    *snapshot
+}
+
+// SnapshotManager sends each player one WorldSnapshot per tick with the entities in its
+// AOI, delta encoded against the last snapshot the client acknowledged
+type SnapshotManager struct {
+	mu      sync.Mutex
+	clients map[uint64]*snapshotClient
+
+	sent  atomic.Uint64
+	full  atomic.Uint64
+	bytes atomic.Uint64
+}
+
+// GetSnapshotManager returns the SnapshotManager
+func GetSnapshotManager() *SnapshotManager {
+	snapshotManagerOnce.Do(func() {
+		snapshotManager = &SnapshotManager{
+			clients: make(map[uint64]*snapshotClient),
+		}
+	})
+
+	return snapshotManager
+}
+
+// RegisterHandlers registers the snapshot message handlers
+func (sm *SnapshotManager) RegisterHandlers(d *Dispatcher) {
+	d.Handle((*pb.GameMessage_SnapshotAck)(nil), sm.handleSnapshotAck, RequireLogin())
+}
+
+func (sm *SnapshotManager) handleSnapshotAck(session *Session, message *pb.GameMessage) {
+	sm.client(session).ack(message.GetSnapshotAck().Sequence)
+}
+
+// Flush sends every player a snapshot of the entities it sees. Players whose view
+// didn't change since the last snapshot get nothing. Called from the world loop at the end of each tick.
+func (sm *SnapshotManager) Flush() {
+	aoi := GetAOIManager()
+	for _, player := range GetPlayerManager().ListPlayers() {
+		session := player.Session()
+		client := sm.client(session)
+		entities := captureState(aoi.Visible(player.Name))
+		message := client.next(entities)
+		if message == nil {
+			continue
+		}
+
+		// 보내지 못한 스냅샷은 기록하지 않아야 다음 틱에 다시 만들어진다
+		packet := GetNetManager().MakePacket(message)
+		if packet == nil || session.SendPacket(packet) != nil {
+			continue
+		}
+		client.sent(message.GetWorldSnapshot(), entities)
+		sm.sent.Add(1)
+		sm.bytes.Add(uint64(len(packet)))
+		if message.GetWorldSnapshot().Baseline == 0 {
+			sm.full.Add(1)
+		}
+	}
+}
+
+// Stats returns the snapshot counters
+func (sm *SnapshotManager) Stats() SnapshotStats {
+	return SnapshotStats{
+		Sent:  sm.sent.Load(),
+		Full:  sm.full.Load(),
+		Bytes: sm.bytes.Load(),
+	}
+}
+
+// client returns the snapshot state of session, creating it on first use
+func (sm *SnapshotManager) client(session *Session) *snapshotClient {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	client, exists := sm.clients[session.ID()]
+	if !exists {
+		client = &snapshotClient{}
+		sm.clients[session.ID()] = client
+
+		// 세션이 닫히면 스냅샷 기록을 정리한다
+		go func() {
+			<-session.Closed()
+			sm.mu.Lock()
+			delete(sm.clients, session.ID())
+			sm.mu.Unlock()
+		}()
+	}
+	return client
+}
+
+// captureState reads the current state of the entities in a player's view
+func captureState(visible []AOIEvent) map[EntityRef]entityState {
+	pm := GetPlayerManager()
+	entities := make(map[EntityRef]entityState, len(visible))
+	for _, event := range visible {
+		switch event.Entity.Kind {
+		case EntityPlayer:
+			player, err := pm.GetPlayer(event.Entity.PlayerId)
+			if err != nil {
+				continue
+			}
+			pos, rotationY := player.Transform()
+			entities[event.Entity] = entityState{X: pos.X, Y: pos.Y, Z: pos.Z, RotationY: rotationY}
+		case EntityMonster:
+			status, alive := GetMonsterManager().MonsterStatus(event.Entity.MonsterId)
+			if !alive {
+				continue
+			}
+			entities[event.Entity] = entityState{
+				X:        status.Pos.X,
+				Z:        status.Pos.Z,
+				HP:       status.HP,
+				Action:   status.Action,
+				TargetId: status.TargetId,
+			}
+		}
+	}
+	return entities
+}
+
+// next returns entities as the next snapshot encoded against the acked baseline, or nil
+// when nothing changed since the last snapshot sent. Call sent once it went out.
+func (c *snapshotClient) next(entities map[EntityRef]entityState) *pb.GameMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var last map[EntityRef]entityState
+	if len(c.history) > 0 {
+		last = c.history[len(c.history)-1].entities
+	}
+	if sameEntities(last, entities) {
+		return nil
+	}
+
+	message := &pb.WorldSnapshot{Sequence: c.sequence + 1}
+	var baseline map[EntityRef]entityState
+	if c.acked != nil {
+		message.Baseline = c.acked.sequence
+		baseline = c.acked.entities
+	}
+
+	// 기준 스냅샷과 달라진 엔티티만 담는다
+	for ref, state := range entities {
+		if old, exists := baseline[ref]; exists && old == state {
+			continue
+		}
+		switch ref.Kind {
+		case EntityPlayer:
+			message.Players = append(message.Players, &pb.PlayerState{
+				PlayerId:  ref.PlayerId,
+				X:         state.X,
+				Y:         state.Y,
+				Z:         state.Z,
+				RotationY: state.RotationY,
+			})
+		case EntityMonster:
+			message.Monsters = append(message.Monsters, &pb.MonsterState{
+				MonsterId: ref.MonsterId,
+				X:         state.X,
+				Z:         state.Z,
+				Hp:        int32(state.HP),
+				Action:    monsterAction(state.Action),
+				TargetId:  state.TargetId,
+			})
+		}
+	}
+	for ref := range baseline {
+		if _, exists := entities[ref]; exists {
+			continue
+		}
+		switch ref.Kind {
+		case EntityPlayer:
+			message.RemovedPlayers = append(message.RemovedPlayers, ref.PlayerId)
+		case EntityMonster:
+			message.RemovedMonsters = append(message.RemovedMonsters, ref.MonsterId)
+		}
+	}
+
+	return &pb.GameMessage{
+		Message: &pb.GameMessage_WorldSnapshot{WorldSnapshot: message},
+	}
+}
+
+// sent records message, made by next from entities, as sent. Once the acked snapshot falls out
+// of the history the client has to start over from a full snapshot.
+func (c *snapshotClient) sent(message *pb.WorldSnapshot, entities map[EntityRef]entityState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sequence = message.Sequence
+	c.history = append(c.history, &snapshot{sequence: c.sequence, entities: entities})
+	if len(c.history) > snapshotHistorySize {
+		c.history = c.history[len(c.history)-snapshotHistorySize:]
+		if c.acked != nil && c.acked.sequence < c.history[0].sequence {
+			c.acked = nil
+		}
+	}
+}
+
+// ack makes sequence the baseline for later snapshots. Unknown or stale sequences are ignored.
+func (c *snapshotClient) ack(sequence uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, s := range c.history {
+		if s.sequence == sequence {
+			c.acked = s
+			// 확인된 스냅샷보다 오래된 기록은 더 이상 기준이 될 수 없다
+			c.history = c.history[i:]
+			return
+		}
+	}
+}
+
+func monsterAction(action behavior.Action) pb.MonsterAction {
+	switch action {
+	case behavior.ActionPatrol:
+		return pb.MonsterAction_MONSTER_PATROL
+	case behavior.ActionChase:
+		return pb.MonsterAction_MONSTER_CHASE
+	case behavior.ActionAttack:
+		return pb.MonsterAction_MONSTER_ATTACK
+	default:
+		return pb.MonsterAction_MONSTER_IDLE
+	}
+}
+
+func sameEntities(a, b map[EntityRef]entityState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for ref, state := range a {
+		if other, exists := b[ref]; !exists || other != state {
+			return false
+		}
+	}
+	return true
+}
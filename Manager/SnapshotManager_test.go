@@ -0,0 +1,132 @@
+package manager
+
+import (
+	"slices"
+	"testing"
+
+	pb "testServer/Messages"
+)
+
+// send makes and records the next snapshot as the world loop does, or returns nil when nothing changed
+func send(c *snapshotClient, entities map[EntityRef]entityState) *pb.WorldSnapshot {
+	message := c.next(entities)
+	if message == nil {
+		return nil
+	}
+	c.sent(message.GetWorldSnapshot(), entities)
+	return message.GetWorldSnapshot()
+}
+
+func monsterIds(states []*pb.MonsterState) []int32 {
+	ids := make([]int32, 0, len(states))
+	for _, state := range states {
+		ids = append(ids, state.MonsterId)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestSnapshotDeltas(t *testing.T) {
+	c := &snapshotClient{}
+	first := map[EntityRef]entityState{
+		MonsterRef(1):  {X: 1, HP: 10},
+		MonsterRef(2):  {X: 2, HP: 10},
+		PlayerRef("a"): {X: 3},
+	}
+
+	// 확인받은 스냅샷이 없으면 전체를 보낸다
+	full := send(c, first)
+	if full.Sequence != 1 || full.Baseline != 0 || len(full.Monsters) != 2 || len(full.Players) != 1 {
+		t.Fatalf("first snapshot = %v, want a full snapshot of everything", full)
+	}
+	if again := send(c, first); again != nil {
+		t.Fatalf("unchanged state sent %v", again)
+	}
+
+	// ack 전에는 계속 전체를 보낸다
+	second := map[EntityRef]entityState{
+		MonsterRef(1):  {X: 1, HP: 5},
+		MonsterRef(2):  {X: 2, HP: 10},
+		PlayerRef("a"): {X: 3},
+	}
+	if unacked := send(c, second); unacked.Baseline != 0 || len(unacked.Monsters) != 2 {
+		t.Fatalf("snapshot before any ack = %v, want full", unacked)
+	}
+
+	// 확인받은 스냅샷 대비 달라진 엔티티와 사라진 엔티티만 보낸다
+	c.ack(1)
+	third := map[EntityRef]entityState{
+		MonsterRef(1):  {X: 1, HP: 5},
+		MonsterRef(3):  {X: 4, HP: 10},
+		PlayerRef("a"): {X: 3},
+	}
+	delta := send(c, third)
+	if delta.Sequence != 3 || delta.Baseline != 1 {
+		t.Fatalf("delta is %d against %d, want 3 against 1", delta.Sequence, delta.Baseline)
+	}
+	if ids := monsterIds(delta.Monsters); !slices.Equal(ids, []int32{1, 3}) {
+		t.Fatalf("delta carries monsters %v, want the changed 1 and the new 3", ids)
+	}
+	if len(delta.Players) != 0 {
+		t.Fatalf("delta carries unchanged players %v", delta.Players)
+	}
+	if !slices.Equal(delta.RemovedMonsters, []int32{2}) || len(delta.RemovedPlayers) != 0 {
+		t.Fatalf("delta removes monsters %v and players %v, want monster 2", delta.RemovedMonsters, delta.RemovedPlayers)
+	}
+}
+
+func TestSnapshotStaleAcks(t *testing.T) {
+	c := &snapshotClient{}
+	state := func(hp int) map[EntityRef]entityState {
+		return map[EntityRef]entityState{MonsterRef(1): {HP: hp}}
+	}
+	for hp := 1; hp <= 3; hp++ {
+		send(c, state(hp))
+	}
+
+	c.ack(2)
+	// 보낸 적 없는 번호나 이미 확인된 것보다 오래된 번호는 무시한다
+	c.ack(99)
+	c.ack(1)
+	if got := send(c, state(4)); got.Baseline != 2 {
+		t.Fatalf("baseline = %d after stale acks, want 2", got.Baseline)
+	}
+}
+
+func TestSnapshotAckTooFarBehind(t *testing.T) {
+	c := &snapshotClient{}
+	state := func(hp int) map[EntityRef]entityState {
+		return map[EntityRef]entityState{MonsterRef(1): {HP: hp}}
+	}
+	send(c, state(0))
+	c.ack(1)
+
+	// 확인된 스냅샷이 기록 밖으로 밀려날 때까지는 그것을 기준으로 삼는다
+	for hp := 1; hp < snapshotHistorySize; hp++ {
+		if got := send(c, state(hp)); got.Baseline != 1 {
+			t.Fatalf("snapshot %d has baseline %d, want 1", got.Sequence, got.Baseline)
+		}
+	}
+	send(c, state(snapshotHistorySize))
+	if got := send(c, state(-1)); got.Baseline != 0 {
+		t.Fatalf("snapshot %d has baseline %d after the ack fell out of the history, want a full snapshot", got.Sequence, got.Baseline)
+	}
+	c.ack(1)
+	if got := send(c, state(-2)); got.Baseline != 0 {
+		t.Fatalf("ack of a forgotten snapshot became baseline %d", got.Baseline)
+	}
+}
+
+func TestUnsentSnapshotIsNotRecorded(t *testing.T) {
+	c := &snapshotClient{}
+	entities := map[EntityRef]entityState{MonsterRef(1): {HP: 1}}
+
+	// 패킷을 만들거나 보내지 못해 sent가 불리지 않으면 다음 틱에 같은 스냅샷을 다시 만든다
+	if c.next(entities) == nil {
+		t.Fatal("first snapshot was empty")
+	}
+	retry := c.next(entities)
+	if retry == nil || retry.GetWorldSnapshot().Sequence != 1 {
+		t.Fatalf("retry = %v, want snapshot 1 again", retry)
+	}
+}
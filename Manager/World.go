@@ -0,0 +1,166 @@
+package manager
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"testServer/config"
+
+	pb "testServer/Messages"
+)
+
+const (
+	DefaultTickRate       = 20
+	DefaultInputQueueSize = 4096
+)
+
+// TickStats holds timing information about the world loop, for tuning the tick rate
+type TickStats struct {
+	TickRate     int
+	Ticks        uint64
+	Overruns     uint64
+	LastDuration time.Duration
+	AvgDuration  time.Duration
+	MaxDuration  time.Duration
+}
+
+// World owns the fixed-rate simulation loop. Player inputs are queued from the
+// connection goroutines and applied at the start of each tick.
+type World struct {
+	tickRate int
+	interval time.Duration
+	inputs   chan *pb.PlayerPosition
+
+	statsMu       sync.Mutex
+	stats         TickStats
+	totalDuration time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+var (
+	world     *World
+	worldOnce sync.Once
+)
+
+// GetWorld returns the world loop
+func GetWorld() *World {
+	worldOnce.Do(func() {
+		world = NewWorld(config.Default().World.TickRate)
+	})
+
+	return world
+}
+
+// NewWorld creates a world that ticks tickRate times per second
+func NewWorld(tickRate int) *World {
+	if tickRate <= 0 {
+		tickRate = DefaultTickRate
+	}
+	return &World{
+		tickRate: tickRate,
+		interval: time.Second / time.Duration(tickRate),
+		inputs:   make(chan *pb.PlayerPosition, DefaultInputQueueSize),
+		stats:    TickStats{TickRate: tickRate},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs the loop on its own goroutine
+func (w *World) Start() {
+	go w.run()
+}
+
+// Stop ends the loop and waits for the current tick to finish
+func (w *World) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+	<-w.done
+}
+
+// QueueMove queues a position update to be applied on the next tick.
+// Moves arriving after Stop are ignored.
+func (w *World) QueueMove(position *pb.PlayerPosition) {
+	select {
+	case <-w.stop:
+		return
+	default:
+	}
+
+	select {
+	case w.inputs <- position:
+	default:
+		log.Printf("World input queue full, dropping move from %s", position.PlayerId)
+	}
+}
+
+// Stats returns a copy of the tick timing stats
+func (w *World) Stats() TickStats {
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
+	return w.stats
+}
+
+func (w *World) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case now := <-ticker.C:
+			dt := now.Sub(last)
+			last = now
+
+			start := time.Now()
+			w.Tick(dt)
+			w.recordTick(time.Since(start))
+		}
+	}
+}
+
+// Tick advances the simulation by dt, then sends every player one snapshot of what changed
+func (w *World) Tick(dt time.Duration) {
+	w.processInputs()
+	GetMonsterManager().Update(dt)
+	GetSnapshotManager().Flush()
+}
+
+// processInputs applies every queued input
+func (w *World) processInputs() {
+	for {
+		select {
+		case position := <-w.inputs:
+			if events, ok := GetPlayerManager().MovePlayer(position); ok {
+				DeliverAOIEvents(events)
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (w *World) recordTick(elapsed time.Duration) {
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
+
+	w.stats.Ticks++
+	w.stats.LastDuration = elapsed
+	w.totalDuration += elapsed
+	w.stats.AvgDuration = w.totalDuration / time.Duration(w.stats.Ticks)
+	if elapsed > w.stats.MaxDuration {
+		w.stats.MaxDuration = elapsed
+	}
+	if elapsed > w.interval {
+		w.stats.Overruns++
+	}
+}
@@ -20,6 +20,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 몬스터가 지금 하고 있는 행동
+type MonsterAction int32
+
+const (
+	MonsterAction_MONSTER_IDLE   MonsterAction = 0
+	MonsterAction_MONSTER_PATROL MonsterAction = 1
+	MonsterAction_MONSTER_CHASE  MonsterAction = 2
+	MonsterAction_MONSTER_ATTACK MonsterAction = 3
+)
+
+// Enum value maps for MonsterAction.
+var (
+	MonsterAction_name = map[int32]string{
+		0: "MONSTER_IDLE",
+		1: "MONSTER_PATROL",
+		2: "MONSTER_CHASE",
+		3: "MONSTER_ATTACK",
+	}
+	MonsterAction_value = map[string]int32{
+		"MONSTER_IDLE":   0,
+		"MONSTER_PATROL": 1,
+		"MONSTER_CHASE":  2,
+		"MONSTER_ATTACK": 3,
+	}
+)
+
+func (x MonsterAction) Enum() *MonsterAction {
+	p := new(MonsterAction)
+	*p = x
+	return p
+}
+
+func (x MonsterAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MonsterAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_GameMessage_proto_enumTypes[0].Descriptor()
+}
+
+func (MonsterAction) Type() protoreflect.EnumType {
+	return &file_GameMessage_proto_enumTypes[0]
+}
+
+func (x MonsterAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MonsterAction.Descriptor instead.
+func (MonsterAction) EnumDescriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{0}
+}
+
 type NavV3 struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -434,12 +487,15 @@ func (x *ChatMessage) GetContent() string {
 	return ""
 }
 
+// password 또는 이전 로그인에서 받은 token 중 하나로 인증한다
 type LoginMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	PlayerId string `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Token    string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
 }
 
 func (x *LoginMessage) Reset() {
@@ -479,6 +535,143 @@ func (x *LoginMessage) GetPlayerId() string {
 	return ""
 }
 
+func (x *LoginMessage) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *LoginMessage) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type LoginResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success  bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	PlayerId string `protobuf:"bytes,3,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Token    string `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	Resumed  bool   `protobuf:"varint,5,opt,name=resumed,proto3" json:"resumed,omitempty"`
+}
+
+func (x *LoginResult) Reset() {
+	*x = LoginResult{}
+	mi := &file_GameMessage_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResult) ProtoMessage() {}
+
+func (x *LoginResult) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResult.ProtoReflect.Descriptor instead.
+func (*LoginResult) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LoginResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LoginResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *LoginResult) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *LoginResult) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LoginResult) GetResumed() bool {
+	if x != nil {
+		return x.Resumed
+	}
+	return false
+}
+
+// 서버가 연결을 끊기 직전에 이유와 함께 보낸다
+type KickMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *KickMessage) Reset() {
+	*x = KickMessage{}
+	mi := &file_GameMessage_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KickMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickMessage) ProtoMessage() {}
+
+func (x *KickMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickMessage.ProtoReflect.Descriptor instead.
+func (*KickMessage) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *KickMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type LogoutMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -489,7 +682,7 @@ type LogoutMessage struct {
 
 func (x *LogoutMessage) Reset() {
 	*x = LogoutMessage{}
-	mi := &file_GameMessage_proto_msgTypes[7]
+	mi := &file_GameMessage_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -501,7 +694,7 @@ func (x *LogoutMessage) String() string {
 func (*LogoutMessage) ProtoMessage() {}
 
 func (x *LogoutMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_GameMessage_proto_msgTypes[7]
+	mi := &file_GameMessage_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,7 +707,7 @@ func (x *LogoutMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutMessage.ProtoReflect.Descriptor instead.
 func (*LogoutMessage) Descriptor() ([]byte, []int) {
-	return file_GameMessage_proto_rawDescGZIP(), []int{7}
+	return file_GameMessage_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *LogoutMessage) GetPlayerId() string {
@@ -536,7 +729,7 @@ type SpawnMonster struct {
 
 func (x *SpawnMonster) Reset() {
 	*x = SpawnMonster{}
-	mi := &file_GameMessage_proto_msgTypes[8]
+	mi := &file_GameMessage_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -548,7 +741,7 @@ func (x *SpawnMonster) String() string {
 func (*SpawnMonster) ProtoMessage() {}
 
 func (x *SpawnMonster) ProtoReflect() protoreflect.Message {
-	mi := &file_GameMessage_proto_msgTypes[8]
+	mi := &file_GameMessage_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -561,7 +754,7 @@ func (x *SpawnMonster) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpawnMonster.ProtoReflect.Descriptor instead.
 func (*SpawnMonster) Descriptor() ([]byte, []int) {
-	return file_GameMessage_proto_rawDescGZIP(), []int{8}
+	return file_GameMessage_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SpawnMonster) GetX() float32 {
@@ -585,39 +778,29 @@ func (x *SpawnMonster) GetMonsterId() int32 {
 	return 0
 }
 
-type GameMessage struct {
+type ServerShutdown struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Message:
-	//
-	//	*GameMessage_PlayerPosition
-	//	*GameMessage_Chat
-	//	*GameMessage_Login
-	//	*GameMessage_SpawnMyPlayer
-	//	*GameMessage_SpawnOtherPlayer
-	//	*GameMessage_Logout
-	//	*GameMessage_PathTest
-	//	*GameMessage_SpawnMonster
-	Message isGameMessage_Message `protobuf_oneof:"message"`
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *GameMessage) Reset() {
-	*x = GameMessage{}
-	mi := &file_GameMessage_proto_msgTypes[9]
+func (x *ServerShutdown) Reset() {
+	*x = ServerShutdown{}
+	mi := &file_GameMessage_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GameMessage) String() string {
+func (x *ServerShutdown) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GameMessage) ProtoMessage() {}
+func (*ServerShutdown) ProtoMessage() {}
 
-func (x *GameMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_GameMessage_proto_msgTypes[9]
+func (x *ServerShutdown) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,111 +811,1056 @@ func (x *GameMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GameMessage.ProtoReflect.Descriptor instead.
-func (*GameMessage) Descriptor() ([]byte, []int) {
-	return file_GameMessage_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ServerShutdown.ProtoReflect.Descriptor instead.
+func (*ServerShutdown) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{11}
 }
 
-func (m *GameMessage) GetMessage() isGameMessage_Message {
-	if m != nil {
-		return m.Message
+func (x *ServerShutdown) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-func (x *GameMessage) GetPlayerPosition() *PlayerPosition {
-	if x, ok := x.GetMessage().(*GameMessage_PlayerPosition); ok {
-		return x.PlayerPosition
-	}
-	return nil
-}
+// 서버와 클라이언트 모두 보낼 수 있다. 받은 쪽은 같은 sequence로 Pong을 돌려준다.
+type Ping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *GameMessage) GetChat() *ChatMessage {
-	if x, ok := x.GetMessage().(*GameMessage_Chat); ok {
-		return x.Chat
-	}
-	return nil
+	Sequence int64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	SentAt   int64 `protobuf:"varint,2,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
 }
 
-func (x *GameMessage) GetLogin() *LoginMessage {
-	if x, ok := x.GetMessage().(*GameMessage_Login); ok {
-		return x.Login
-	}
-	return nil
+func (x *Ping) Reset() {
+	*x = Ping{}
+	mi := &file_GameMessage_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *GameMessage) GetSpawnMyPlayer() *SpawnMyPlayer {
-	if x, ok := x.GetMessage().(*GameMessage_SpawnMyPlayer); ok {
-		return x.SpawnMyPlayer
-	}
-	return nil
+func (x *Ping) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *GameMessage) GetSpawnOtherPlayer() *SpawnOtherPlayer {
-	if x, ok := x.GetMessage().(*GameMessage_SpawnOtherPlayer); ok {
-		return x.SpawnOtherPlayer
+func (*Ping) ProtoMessage() {}
+
+func (x *Ping) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GameMessage) GetLogout() *LogoutMessage {
-	if x, ok := x.GetMessage().(*GameMessage_Logout); ok {
-		return x.Logout
-	}
-	return nil
+// Deprecated: Use Ping.ProtoReflect.Descriptor instead.
+func (*Ping) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GameMessage) GetPathTest() *PathTest {
-	if x, ok := x.GetMessage().(*GameMessage_PathTest); ok {
-		return x.PathTest
+func (x *Ping) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
 	}
-	return nil
+	return 0
 }
 
-func (x *GameMessage) GetSpawnMonster() *SpawnMonster {
-	if x, ok := x.GetMessage().(*GameMessage_SpawnMonster); ok {
-		return x.SpawnMonster
+func (x *Ping) GetSentAt() int64 {
+	if x != nil {
+		return x.SentAt
 	}
-	return nil
+	return 0
 }
 
-type isGameMessage_Message interface {
-	isGameMessage_Message()
-}
+type Pong struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-type GameMessage_PlayerPosition struct {
-	PlayerPosition *PlayerPosition `protobuf:"bytes,1,opt,name=player_position,json=playerPosition,proto3,oneof"`
+	Sequence int64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	SentAt   int64 `protobuf:"varint,2,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
 }
 
-type GameMessage_Chat struct {
-	Chat *ChatMessage `protobuf:"bytes,2,opt,name=chat,proto3,oneof"`
+func (x *Pong) Reset() {
+	*x = Pong{}
+	mi := &file_GameMessage_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type GameMessage_Login struct {
-	Login *LoginMessage `protobuf:"bytes,3,opt,name=login,proto3,oneof"`
+func (x *Pong) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type GameMessage_SpawnMyPlayer struct {
-	SpawnMyPlayer *SpawnMyPlayer `protobuf:"bytes,4,opt,name=spawnMyPlayer,proto3,oneof"`
-}
+func (*Pong) ProtoMessage() {}
 
-type GameMessage_SpawnOtherPlayer struct {
-	SpawnOtherPlayer *SpawnOtherPlayer `protobuf:"bytes,5,opt,name=spawnOtherPlayer,proto3,oneof"`
+func (x *Pong) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type GameMessage_Logout struct {
-	Logout *LogoutMessage `protobuf:"bytes,6,opt,name=logout,proto3,oneof"`
+// Deprecated: Use Pong.ProtoReflect.Descriptor instead.
+func (*Pong) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{13}
 }
 
-type GameMessage_PathTest struct {
-	PathTest *PathTest `protobuf:"bytes,7,opt,name=pathTest,proto3,oneof"`
+func (x *Pong) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
 }
 
-type GameMessage_SpawnMonster struct {
-	SpawnMonster *SpawnMonster `protobuf:"bytes,8,opt,name=spawnMonster,proto3,oneof"`
+func (x *Pong) GetSentAt() int64 {
+	if x != nil {
+		return x.SentAt
+	}
+	return 0
 }
 
-func (*GameMessage_PlayerPosition) isGameMessage_Message() {}
+// 관심 영역(AOI) 밖으로 나간 엔티티를 지운다
+type DespawnOtherPlayer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId string `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+}
+
+func (x *DespawnOtherPlayer) Reset() {
+	*x = DespawnOtherPlayer{}
+	mi := &file_GameMessage_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DespawnOtherPlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DespawnOtherPlayer) ProtoMessage() {}
+
+func (x *DespawnOtherPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DespawnOtherPlayer.ProtoReflect.Descriptor instead.
+func (*DespawnOtherPlayer) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DespawnOtherPlayer) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type DespawnMonster struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MonsterId int32 `protobuf:"varint,1,opt,name=monsterId,proto3" json:"monsterId,omitempty"`
+}
+
+func (x *DespawnMonster) Reset() {
+	*x = DespawnMonster{}
+	mi := &file_GameMessage_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DespawnMonster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DespawnMonster) ProtoMessage() {}
+
+func (x *DespawnMonster) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DespawnMonster.ProtoReflect.Descriptor instead.
+func (*DespawnMonster) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DespawnMonster) GetMonsterId() int32 {
+	if x != nil {
+		return x.MonsterId
+	}
+	return 0
+}
+
+// 플레이어가 몬스터를 공격한다. 사거리와 피해량은 서버가 정한다.
+type AttackMonster struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MonsterId int32 `protobuf:"varint,1,opt,name=monsterId,proto3" json:"monsterId,omitempty"`
+}
+
+func (x *AttackMonster) Reset() {
+	*x = AttackMonster{}
+	mi := &file_GameMessage_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttackMonster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttackMonster) ProtoMessage() {}
+
+func (x *AttackMonster) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttackMonster.ProtoReflect.Descriptor instead.
+func (*AttackMonster) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AttackMonster) GetMonsterId() int32 {
+	if x != nil {
+		return x.MonsterId
+	}
+	return 0
+}
+
+// 서버가 받아들이지 않은 이동을 되돌린다. 클라이언트는 이 위치로 즉시 옮겨야 한다.
+type PositionCorrection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X         float32 `protobuf:"fixed32,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y         float32 `protobuf:"fixed32,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z         float32 `protobuf:"fixed32,3,opt,name=z,proto3" json:"z,omitempty"`
+	RotationY float32 `protobuf:"fixed32,4,opt,name=rotation_y,json=rotationY,proto3" json:"rotation_y,omitempty"`
+	Reason    string  `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *PositionCorrection) Reset() {
+	*x = PositionCorrection{}
+	mi := &file_GameMessage_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionCorrection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionCorrection) ProtoMessage() {}
+
+func (x *PositionCorrection) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionCorrection.ProtoReflect.Descriptor instead.
+func (*PositionCorrection) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PositionCorrection) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *PositionCorrection) GetY() float32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *PositionCorrection) GetZ() float32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+func (x *PositionCorrection) GetRotationY() float32 {
+	if x != nil {
+		return x.RotationY
+	}
+	return 0
+}
+
+func (x *PositionCorrection) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PlayerState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId  string  `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	X         float32 `protobuf:"fixed32,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y         float32 `protobuf:"fixed32,3,opt,name=y,proto3" json:"y,omitempty"`
+	Z         float32 `protobuf:"fixed32,4,opt,name=z,proto3" json:"z,omitempty"`
+	RotationY float32 `protobuf:"fixed32,5,opt,name=rotation_y,json=rotationY,proto3" json:"rotation_y,omitempty"`
+}
+
+func (x *PlayerState) Reset() {
+	*x = PlayerState{}
+	mi := &file_GameMessage_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerState) ProtoMessage() {}
+
+func (x *PlayerState) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerState.ProtoReflect.Descriptor instead.
+func (*PlayerState) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PlayerState) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerState) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *PlayerState) GetY() float32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *PlayerState) GetZ() float32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+func (x *PlayerState) GetRotationY() float32 {
+	if x != nil {
+		return x.RotationY
+	}
+	return 0
+}
+
+// targetId는 추적하거나 공격 중인 플레이어 (없으면 비어 있다)
+type MonsterState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MonsterId int32         `protobuf:"varint,1,opt,name=monsterId,proto3" json:"monsterId,omitempty"`
+	X         float32       `protobuf:"fixed32,2,opt,name=x,proto3" json:"x,omitempty"`
+	Z         float32       `protobuf:"fixed32,3,opt,name=z,proto3" json:"z,omitempty"`
+	Hp        int32         `protobuf:"varint,4,opt,name=hp,proto3" json:"hp,omitempty"`
+	Action    MonsterAction `protobuf:"varint,5,opt,name=action,proto3,enum=game.MonsterAction" json:"action,omitempty"`
+	TargetId  string        `protobuf:"bytes,6,opt,name=targetId,proto3" json:"targetId,omitempty"`
+}
+
+func (x *MonsterState) Reset() {
+	*x = MonsterState{}
+	mi := &file_GameMessage_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonsterState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonsterState) ProtoMessage() {}
+
+func (x *MonsterState) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonsterState.ProtoReflect.Descriptor instead.
+func (*MonsterState) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MonsterState) GetMonsterId() int32 {
+	if x != nil {
+		return x.MonsterId
+	}
+	return 0
+}
+
+func (x *MonsterState) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *MonsterState) GetZ() float32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+func (x *MonsterState) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *MonsterState) GetAction() MonsterAction {
+	if x != nil {
+		return x.Action
+	}
+	return MonsterAction_MONSTER_IDLE
+}
+
+func (x *MonsterState) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+// 몬스터가 플레이어를 공격한 순간 시야 안의 플레이어들에게 보낸다
+type MonsterAttack struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MonsterId int32  `protobuf:"varint,1,opt,name=monsterId,proto3" json:"monsterId,omitempty"`
+	TargetId  string `protobuf:"bytes,2,opt,name=targetId,proto3" json:"targetId,omitempty"`
+	Damage    int32  `protobuf:"varint,3,opt,name=damage,proto3" json:"damage,omitempty"`
+}
+
+func (x *MonsterAttack) Reset() {
+	*x = MonsterAttack{}
+	mi := &file_GameMessage_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonsterAttack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonsterAttack) ProtoMessage() {}
+
+func (x *MonsterAttack) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonsterAttack.ProtoReflect.Descriptor instead.
+func (*MonsterAttack) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MonsterAttack) GetMonsterId() int32 {
+	if x != nil {
+		return x.MonsterId
+	}
+	return 0
+}
+
+func (x *MonsterAttack) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *MonsterAttack) GetDamage() int32 {
+	if x != nil {
+		return x.Damage
+	}
+	return 0
+}
+
+// 몬스터가 죽은 순간 시야 안의 플레이어들에게 보낸다. 뒤이어 DespawnMonster가 온다.
+type MonsterDied struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MonsterId int32  `protobuf:"varint,1,opt,name=monsterId,proto3" json:"monsterId,omitempty"`
+	KillerId  string `protobuf:"bytes,2,opt,name=killerId,proto3" json:"killerId,omitempty"`
+}
+
+func (x *MonsterDied) Reset() {
+	*x = MonsterDied{}
+	mi := &file_GameMessage_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonsterDied) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonsterDied) ProtoMessage() {}
+
+func (x *MonsterDied) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonsterDied.ProtoReflect.Descriptor instead.
+func (*MonsterDied) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MonsterDied) GetMonsterId() int32 {
+	if x != nil {
+		return x.MonsterId
+	}
+	return 0
+}
+
+func (x *MonsterDied) GetKillerId() string {
+	if x != nil {
+		return x.KillerId
+	}
+	return ""
+}
+
+// 틱마다 시야 안 엔티티의 상태를 한 번에 보낸다. baseline이 0이면 전체 상태이고,
+// 아니면 클라이언트가 확인한 baseline 스냅샷에서 바뀐 엔티티와 사라진 엔티티만 담는다.
+type WorldSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sequence        uint32          `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Baseline        uint32          `protobuf:"varint,2,opt,name=baseline,proto3" json:"baseline,omitempty"`
+	Players         []*PlayerState  `protobuf:"bytes,3,rep,name=players,proto3" json:"players,omitempty"`
+	Monsters        []*MonsterState `protobuf:"bytes,4,rep,name=monsters,proto3" json:"monsters,omitempty"`
+	RemovedPlayers  []string        `protobuf:"bytes,5,rep,name=removedPlayers,proto3" json:"removedPlayers,omitempty"`
+	RemovedMonsters []int32         `protobuf:"varint,6,rep,packed,name=removedMonsters,proto3" json:"removedMonsters,omitempty"`
+}
+
+func (x *WorldSnapshot) Reset() {
+	*x = WorldSnapshot{}
+	mi := &file_GameMessage_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorldSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorldSnapshot) ProtoMessage() {}
+
+func (x *WorldSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorldSnapshot.ProtoReflect.Descriptor instead.
+func (*WorldSnapshot) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WorldSnapshot) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *WorldSnapshot) GetBaseline() uint32 {
+	if x != nil {
+		return x.Baseline
+	}
+	return 0
+}
+
+func (x *WorldSnapshot) GetPlayers() []*PlayerState {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *WorldSnapshot) GetMonsters() []*MonsterState {
+	if x != nil {
+		return x.Monsters
+	}
+	return nil
+}
+
+func (x *WorldSnapshot) GetRemovedPlayers() []string {
+	if x != nil {
+		return x.RemovedPlayers
+	}
+	return nil
+}
+
+func (x *WorldSnapshot) GetRemovedMonsters() []int32 {
+	if x != nil {
+		return x.RemovedMonsters
+	}
+	return nil
+}
+
+// 클라이언트가 받은 스냅샷을 확인한다. 이후 스냅샷은 이 스냅샷을 기준으로 델타를 만든다.
+type SnapshotAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sequence uint32 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *SnapshotAck) Reset() {
+	*x = SnapshotAck{}
+	mi := &file_GameMessage_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotAck) ProtoMessage() {}
+
+func (x *SnapshotAck) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotAck.ProtoReflect.Descriptor instead.
+func (*SnapshotAck) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SnapshotAck) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type GameMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Message:
+	//
+	//	*GameMessage_PlayerPosition
+	//	*GameMessage_Chat
+	//	*GameMessage_Login
+	//	*GameMessage_SpawnMyPlayer
+	//	*GameMessage_SpawnOtherPlayer
+	//	*GameMessage_Logout
+	//	*GameMessage_PathTest
+	//	*GameMessage_SpawnMonster
+	//	*GameMessage_ServerShutdown
+	//	*GameMessage_Ping
+	//	*GameMessage_Pong
+	//	*GameMessage_LoginResult
+	//	*GameMessage_Kick
+	//	*GameMessage_DespawnOtherPlayer
+	//	*GameMessage_DespawnMonster
+	//	*GameMessage_PositionCorrection
+	//	*GameMessage_WorldSnapshot
+	//	*GameMessage_SnapshotAck
+	//	*GameMessage_AttackMonster
+	//	*GameMessage_MonsterAttack
+	//	*GameMessage_MonsterDied
+	Message isGameMessage_Message `protobuf_oneof:"message"`
+}
+
+func (x *GameMessage) Reset() {
+	*x = GameMessage{}
+	mi := &file_GameMessage_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameMessage) ProtoMessage() {}
+
+func (x *GameMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_GameMessage_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameMessage.ProtoReflect.Descriptor instead.
+func (*GameMessage) Descriptor() ([]byte, []int) {
+	return file_GameMessage_proto_rawDescGZIP(), []int{24}
+}
+
+func (m *GameMessage) GetMessage() isGameMessage_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *GameMessage) GetPlayerPosition() *PlayerPosition {
+	if x, ok := x.GetMessage().(*GameMessage_PlayerPosition); ok {
+		return x.PlayerPosition
+	}
+	return nil
+}
+
+func (x *GameMessage) GetChat() *ChatMessage {
+	if x, ok := x.GetMessage().(*GameMessage_Chat); ok {
+		return x.Chat
+	}
+	return nil
+}
+
+func (x *GameMessage) GetLogin() *LoginMessage {
+	if x, ok := x.GetMessage().(*GameMessage_Login); ok {
+		return x.Login
+	}
+	return nil
+}
+
+func (x *GameMessage) GetSpawnMyPlayer() *SpawnMyPlayer {
+	if x, ok := x.GetMessage().(*GameMessage_SpawnMyPlayer); ok {
+		return x.SpawnMyPlayer
+	}
+	return nil
+}
+
+func (x *GameMessage) GetSpawnOtherPlayer() *SpawnOtherPlayer {
+	if x, ok := x.GetMessage().(*GameMessage_SpawnOtherPlayer); ok {
+		return x.SpawnOtherPlayer
+	}
+	return nil
+}
+
+func (x *GameMessage) GetLogout() *LogoutMessage {
+	if x, ok := x.GetMessage().(*GameMessage_Logout); ok {
+		return x.Logout
+	}
+	return nil
+}
+
+func (x *GameMessage) GetPathTest() *PathTest {
+	if x, ok := x.GetMessage().(*GameMessage_PathTest); ok {
+		return x.PathTest
+	}
+	return nil
+}
+
+func (x *GameMessage) GetSpawnMonster() *SpawnMonster {
+	if x, ok := x.GetMessage().(*GameMessage_SpawnMonster); ok {
+		return x.SpawnMonster
+	}
+	return nil
+}
+
+func (x *GameMessage) GetServerShutdown() *ServerShutdown {
+	if x, ok := x.GetMessage().(*GameMessage_ServerShutdown); ok {
+		return x.ServerShutdown
+	}
+	return nil
+}
+
+func (x *GameMessage) GetPing() *Ping {
+	if x, ok := x.GetMessage().(*GameMessage_Ping); ok {
+		return x.Ping
+	}
+	return nil
+}
+
+func (x *GameMessage) GetPong() *Pong {
+	if x, ok := x.GetMessage().(*GameMessage_Pong); ok {
+		return x.Pong
+	}
+	return nil
+}
+
+func (x *GameMessage) GetLoginResult() *LoginResult {
+	if x, ok := x.GetMessage().(*GameMessage_LoginResult); ok {
+		return x.LoginResult
+	}
+	return nil
+}
+
+func (x *GameMessage) GetKick() *KickMessage {
+	if x, ok := x.GetMessage().(*GameMessage_Kick); ok {
+		return x.Kick
+	}
+	return nil
+}
+
+func (x *GameMessage) GetDespawnOtherPlayer() *DespawnOtherPlayer {
+	if x, ok := x.GetMessage().(*GameMessage_DespawnOtherPlayer); ok {
+		return x.DespawnOtherPlayer
+	}
+	return nil
+}
+
+func (x *GameMessage) GetDespawnMonster() *DespawnMonster {
+	if x, ok := x.GetMessage().(*GameMessage_DespawnMonster); ok {
+		return x.DespawnMonster
+	}
+	return nil
+}
+
+func (x *GameMessage) GetPositionCorrection() *PositionCorrection {
+	if x, ok := x.GetMessage().(*GameMessage_PositionCorrection); ok {
+		return x.PositionCorrection
+	}
+	return nil
+}
+
+func (x *GameMessage) GetWorldSnapshot() *WorldSnapshot {
+	if x, ok := x.GetMessage().(*GameMessage_WorldSnapshot); ok {
+		return x.WorldSnapshot
+	}
+	return nil
+}
+
+func (x *GameMessage) GetSnapshotAck() *SnapshotAck {
+	if x, ok := x.GetMessage().(*GameMessage_SnapshotAck); ok {
+		return x.SnapshotAck
+	}
+	return nil
+}
+
+func (x *GameMessage) GetAttackMonster() *AttackMonster {
+	if x, ok := x.GetMessage().(*GameMessage_AttackMonster); ok {
+		return x.AttackMonster
+	}
+	return nil
+}
+
+func (x *GameMessage) GetMonsterAttack() *MonsterAttack {
+	if x, ok := x.GetMessage().(*GameMessage_MonsterAttack); ok {
+		return x.MonsterAttack
+	}
+	return nil
+}
+
+func (x *GameMessage) GetMonsterDied() *MonsterDied {
+	if x, ok := x.GetMessage().(*GameMessage_MonsterDied); ok {
+		return x.MonsterDied
+	}
+	return nil
+}
+
+type isGameMessage_Message interface {
+	isGameMessage_Message()
+}
+
+type GameMessage_PlayerPosition struct {
+	PlayerPosition *PlayerPosition `protobuf:"bytes,1,opt,name=player_position,json=playerPosition,proto3,oneof"`
+}
+
+type GameMessage_Chat struct {
+	Chat *ChatMessage `protobuf:"bytes,2,opt,name=chat,proto3,oneof"`
+}
+
+type GameMessage_Login struct {
+	Login *LoginMessage `protobuf:"bytes,3,opt,name=login,proto3,oneof"`
+}
+
+type GameMessage_SpawnMyPlayer struct {
+	SpawnMyPlayer *SpawnMyPlayer `protobuf:"bytes,4,opt,name=spawnMyPlayer,proto3,oneof"`
+}
+
+type GameMessage_SpawnOtherPlayer struct {
+	SpawnOtherPlayer *SpawnOtherPlayer `protobuf:"bytes,5,opt,name=spawnOtherPlayer,proto3,oneof"`
+}
+
+type GameMessage_Logout struct {
+	Logout *LogoutMessage `protobuf:"bytes,6,opt,name=logout,proto3,oneof"`
+}
+
+type GameMessage_PathTest struct {
+	PathTest *PathTest `protobuf:"bytes,7,opt,name=pathTest,proto3,oneof"`
+}
+
+type GameMessage_SpawnMonster struct {
+	SpawnMonster *SpawnMonster `protobuf:"bytes,8,opt,name=spawnMonster,proto3,oneof"`
+}
+
+type GameMessage_ServerShutdown struct {
+	ServerShutdown *ServerShutdown `protobuf:"bytes,9,opt,name=serverShutdown,proto3,oneof"`
+}
+
+type GameMessage_Ping struct {
+	Ping *Ping `protobuf:"bytes,10,opt,name=ping,proto3,oneof"`
+}
+
+type GameMessage_Pong struct {
+	Pong *Pong `protobuf:"bytes,11,opt,name=pong,proto3,oneof"`
+}
+
+type GameMessage_LoginResult struct {
+	LoginResult *LoginResult `protobuf:"bytes,12,opt,name=loginResult,proto3,oneof"`
+}
+
+type GameMessage_Kick struct {
+	Kick *KickMessage `protobuf:"bytes,13,opt,name=kick,proto3,oneof"`
+}
+
+type GameMessage_DespawnOtherPlayer struct {
+	DespawnOtherPlayer *DespawnOtherPlayer `protobuf:"bytes,14,opt,name=despawnOtherPlayer,proto3,oneof"`
+}
+
+type GameMessage_DespawnMonster struct {
+	DespawnMonster *DespawnMonster `protobuf:"bytes,15,opt,name=despawnMonster,proto3,oneof"`
+}
+
+type GameMessage_PositionCorrection struct {
+	PositionCorrection *PositionCorrection `protobuf:"bytes,16,opt,name=positionCorrection,proto3,oneof"`
+}
+
+type GameMessage_WorldSnapshot struct {
+	WorldSnapshot *WorldSnapshot `protobuf:"bytes,17,opt,name=worldSnapshot,proto3,oneof"`
+}
+
+type GameMessage_SnapshotAck struct {
+	SnapshotAck *SnapshotAck `protobuf:"bytes,18,opt,name=snapshotAck,proto3,oneof"`
+}
+
+type GameMessage_AttackMonster struct {
+	AttackMonster *AttackMonster `protobuf:"bytes,19,opt,name=attackMonster,proto3,oneof"`
+}
+
+type GameMessage_MonsterAttack struct {
+	MonsterAttack *MonsterAttack `protobuf:"bytes,20,opt,name=monsterAttack,proto3,oneof"`
+}
+
+type GameMessage_MonsterDied struct {
+	MonsterDied *MonsterDied `protobuf:"bytes,21,opt,name=monsterDied,proto3,oneof"`
+}
+
+func (*GameMessage_PlayerPosition) isGameMessage_Message() {}
 
 func (*GameMessage_Chat) isGameMessage_Message() {}
 
@@ -748,6 +1876,32 @@ func (*GameMessage_PathTest) isGameMessage_Message() {}
 
 func (*GameMessage_SpawnMonster) isGameMessage_Message() {}
 
+func (*GameMessage_ServerShutdown) isGameMessage_Message() {}
+
+func (*GameMessage_Ping) isGameMessage_Message() {}
+
+func (*GameMessage_Pong) isGameMessage_Message() {}
+
+func (*GameMessage_LoginResult) isGameMessage_Message() {}
+
+func (*GameMessage_Kick) isGameMessage_Message() {}
+
+func (*GameMessage_DespawnOtherPlayer) isGameMessage_Message() {}
+
+func (*GameMessage_DespawnMonster) isGameMessage_Message() {}
+
+func (*GameMessage_PositionCorrection) isGameMessage_Message() {}
+
+func (*GameMessage_WorldSnapshot) isGameMessage_Message() {}
+
+func (*GameMessage_SnapshotAck) isGameMessage_Message() {}
+
+func (*GameMessage_AttackMonster) isGameMessage_Message() {}
+
+func (*GameMessage_MonsterAttack) isGameMessage_Message() {}
+
+func (*GameMessage_MonsterDied) isGameMessage_Message() {}
+
 var File_GameMessage_proto protoreflect.FileDescriptor
 
 var file_GameMessage_proto_rawDesc = []byte{
@@ -787,48 +1941,187 @@ var file_GameMessage_proto_rawDesc = []byte{
 	0x0b, 0x43, 0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
 	0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
 	0x6e, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x2a,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x5c,
 	0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a,
 	0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x2b, 0x0a, 0x0d, 0x4c, 0x6f,
-	0x67, 0x6f, 0x75, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70,
-	0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
-	0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x48, 0x0a, 0x0c, 0x53, 0x70, 0x61, 0x77, 0x6e,
-	0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x8b, 0x01, 0x0a,
+	0x0b, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x22, 0x25, 0x0a, 0x0b, 0x4b, 0x69,
+	0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x22, 0x2b, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x48,
+	0x0a, 0x0c, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x12, 0x0c,
+	0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01,
+	0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x7a, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x6f,
+	0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d,
+	0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x22, 0x28, 0x0a, 0x0e, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x22, 0x3b, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x22,
+	0x3b, 0x0a, 0x04, 0x50, 0x6f, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x22, 0x30, 0x0a, 0x12,
+	0x44, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x2e,
+	0x0a, 0x0e, 0x44, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72,
+	0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x22, 0x2d,
+	0x0a, 0x0d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x12,
+	0x1c, 0x0a, 0x09, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x22, 0x75, 0x0a,
+	0x12, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01,
+	0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x79, 0x12,
+	0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x7a, 0x12, 0x1d, 0x0a,
+	0x0a, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x09, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x59, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x22, 0x72, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a,
+	0x01, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x7a, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x72,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x59, 0x22, 0xa1, 0x01, 0x0a, 0x0c, 0x4d, 0x6f, 0x6e,
+	0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x6f, 0x6e,
+	0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x6f,
+	0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02,
-	0x52, 0x01, 0x7a, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x49,
-	0x64, 0x22, 0xc8, 0x03, 0x0a, 0x0b, 0x47, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x73, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6d,
-	0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x48, 0x00, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x04, 0x63, 0x68, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x63, 0x68, 0x61, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x6c,
-	0x6f, 0x67, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x61, 0x6d,
-	0x65, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
-	0x52, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x3b, 0x0a, 0x0d, 0x73, 0x70, 0x61, 0x77, 0x6e,
-	0x4d, 0x79, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
-	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x79, 0x50, 0x6c, 0x61,
-	0x79, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x79, 0x50, 0x6c,
-	0x61, 0x79, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x10, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4f, 0x74, 0x68,
-	0x65, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x4f, 0x74, 0x68, 0x65, 0x72,
-	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x48, 0x00, 0x52, 0x10, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4f,
-	0x74, 0x68, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x06, 0x6c, 0x6f,
-	0x67, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d,
-	0x65, 0x2e, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
-	0x00, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x70, 0x61, 0x74,
-	0x68, 0x54, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x61,
-	0x6d, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x54, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x70,
-	0x61, 0x74, 0x68, 0x54, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0c, 0x73, 0x70, 0x61, 0x77, 0x6e,
-	0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x67, 0x61, 0x6d, 0x65, 0x2e, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65,
-	0x72, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65,
-	0x72, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x0b, 0x5a, 0x09,
-	0x2f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x52, 0x01, 0x7a, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x02, 0x68, 0x70, 0x12, 0x2b, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x4d, 0x6f, 0x6e, 0x73, 0x74,
+	0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x22, 0x61, 0x0a, 0x0d,
+	0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x12, 0x1c, 0x0a,
+	0x09, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x22,
+	0x47, 0x0a, 0x0b, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x44, 0x69, 0x65, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x72, 0x49, 0x64, 0x22, 0xf6, 0x01, 0x0a, 0x0d, 0x57, 0x6f, 0x72,
+	0x6c, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12,
+	0x2e, 0x0a, 0x08, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x08, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x26, 0x0a, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72,
+	0x73, 0x22, 0x29, 0x0a, 0x0b, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x63, 0x6b,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xa9, 0x09, 0x0a,
+	0x0b, 0x47, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3f, 0x0a, 0x0f,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0e, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a,
+	0x04, 0x63, 0x68, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x04, 0x63, 0x68, 0x61, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x4c, 0x6f, 0x67,
+	0x69, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x6f, 0x67,
+	0x69, 0x6e, 0x12, 0x3b, 0x0a, 0x0d, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x79, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x2e, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x79, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x48, 0x00,
+	0x52, 0x0d, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x79, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12,
+	0x44, 0x0a, 0x10, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x2e, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x48, 0x00, 0x52, 0x10, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x4c, 0x6f, 0x67,
+	0x6f, 0x75, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x06, 0x6c, 0x6f,
+	0x67, 0x6f, 0x75, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x68, 0x54, 0x65, 0x73, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x61,
+	0x74, 0x68, 0x54, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x70, 0x61, 0x74, 0x68, 0x54, 0x65,
+	0x73, 0x74, 0x12, 0x38, 0x0a, 0x0c, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x6f, 0x6e, 0x73, 0x74,
+	0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
+	0x53, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0c,
+	0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x0e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x20, 0x0a, 0x04,
+	0x70, 0x69, 0x6e, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x20,
+	0x0a, 0x04, 0x70, 0x6f, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67,
+	0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6f, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x04, 0x70, 0x6f, 0x6e, 0x67,
+	0x12, 0x35, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x4c, 0x6f, 0x67,
+	0x69, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x6c, 0x6f, 0x67, 0x69,
+	0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x6b, 0x69, 0x63, 0x6b, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x4b, 0x69, 0x63,
+	0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6b, 0x69, 0x63, 0x6b,
+	0x12, 0x4a, 0x0a, 0x12, 0x64, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4f, 0x74, 0x68, 0x65, 0x72,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67,
+	0x61, 0x6d, 0x65, 0x2e, 0x44, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4f, 0x74, 0x68, 0x65, 0x72,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x48, 0x00, 0x52, 0x12, 0x64, 0x65, 0x73, 0x70, 0x61, 0x77,
+	0x6e, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x0e,
+	0x64, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x44, 0x65, 0x73, 0x70,
+	0x61, 0x77, 0x6e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0e, 0x64, 0x65,
+	0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x12, 0x4a, 0x0a, 0x12,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
+	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x12, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0d, 0x77, 0x6f, 0x72, 0x6c,
+	0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x35, 0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x41, 0x63, 0x6b, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52,
+	0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x3b, 0x0a, 0x0d,
+	0x61, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x18, 0x13, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63,
+	0x6b, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0d, 0x61, 0x74, 0x74, 0x61,
+	0x63, 0x6b, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0d, 0x6d, 0x6f, 0x6e,
+	0x73, 0x74, 0x65, 0x72, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x41,
+	0x74, 0x74, 0x61, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0d, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72,
+	0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x12, 0x35, 0x0a, 0x0b, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65,
+	0x72, 0x44, 0x69, 0x65, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x2e, 0x4d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x44, 0x69, 0x65, 0x64, 0x48, 0x00,
+	0x52, 0x0b, 0x6d, 0x6f, 0x6e, 0x73, 0x74, 0x65, 0x72, 0x44, 0x69, 0x65, 0x64, 0x42, 0x09, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0x5c, 0x0a, 0x0d, 0x4d, 0x6f, 0x6e, 0x73,
+	0x74, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x0c, 0x4d, 0x4f, 0x4e,
+	0x53, 0x54, 0x45, 0x52, 0x5f, 0x49, 0x44, 0x4c, 0x45, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x4d,
+	0x4f, 0x4e, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x50, 0x41, 0x54, 0x52, 0x4f, 0x4c, 0x10, 0x01, 0x12,
+	0x11, 0x0a, 0x0d, 0x4d, 0x4f, 0x4e, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x43, 0x48, 0x41, 0x53, 0x45,
+	0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x4f, 0x4e, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x41, 0x54,
+	0x54, 0x41, 0x43, 0x4b, 0x10, 0x03, 0x42, 0x0b, 0x5a, 0x09, 0x2f, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -843,34 +2136,67 @@ func file_GameMessage_proto_rawDescGZIP() []byte {
 	return file_GameMessage_proto_rawDescData
 }
 
-var file_GameMessage_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_GameMessage_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_GameMessage_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_GameMessage_proto_goTypes = []any{
-	(*NavV3)(nil),            // 0: game.NavV3
-	(*PathTest)(nil),         // 1: game.PathTest
-	(*PlayerPosition)(nil),   // 2: game.PlayerPosition
-	(*SpawnMyPlayer)(nil),    // 3: game.SpawnMyPlayer
-	(*SpawnOtherPlayer)(nil), // 4: game.SpawnOtherPlayer
-	(*ChatMessage)(nil),      // 5: game.ChatMessage
-	(*LoginMessage)(nil),     // 6: game.LoginMessage
-	(*LogoutMessage)(nil),    // 7: game.LogoutMessage
-	(*SpawnMonster)(nil),     // 8: game.SpawnMonster
-	(*GameMessage)(nil),      // 9: game.GameMessage
+	(MonsterAction)(0),         // 0: game.MonsterAction
+	(*NavV3)(nil),              // 1: game.NavV3
+	(*PathTest)(nil),           // 2: game.PathTest
+	(*PlayerPosition)(nil),     // 3: game.PlayerPosition
+	(*SpawnMyPlayer)(nil),      // 4: game.SpawnMyPlayer
+	(*SpawnOtherPlayer)(nil),   // 5: game.SpawnOtherPlayer
+	(*ChatMessage)(nil),        // 6: game.ChatMessage
+	(*LoginMessage)(nil),       // 7: game.LoginMessage
+	(*LoginResult)(nil),        // 8: game.LoginResult
+	(*KickMessage)(nil),        // 9: game.KickMessage
+	(*LogoutMessage)(nil),      // 10: game.LogoutMessage
+	(*SpawnMonster)(nil),       // 11: game.SpawnMonster
+	(*ServerShutdown)(nil),     // 12: game.ServerShutdown
+	(*Ping)(nil),               // 13: game.Ping
+	(*Pong)(nil),               // 14: game.Pong
+	(*DespawnOtherPlayer)(nil), // 15: game.DespawnOtherPlayer
+	(*DespawnMonster)(nil),     // 16: game.DespawnMonster
+	(*AttackMonster)(nil),      // 17: game.AttackMonster
+	(*PositionCorrection)(nil), // 18: game.PositionCorrection
+	(*PlayerState)(nil),        // 19: game.PlayerState
+	(*MonsterState)(nil),       // 20: game.MonsterState
+	(*MonsterAttack)(nil),      // 21: game.MonsterAttack
+	(*MonsterDied)(nil),        // 22: game.MonsterDied
+	(*WorldSnapshot)(nil),      // 23: game.WorldSnapshot
+	(*SnapshotAck)(nil),        // 24: game.SnapshotAck
+	(*GameMessage)(nil),        // 25: game.GameMessage
 }
 var file_GameMessage_proto_depIdxs = []int32{
-	0, // 0: game.PathTest.paths:type_name -> game.NavV3
-	2, // 1: game.GameMessage.player_position:type_name -> game.PlayerPosition
-	5, // 2: game.GameMessage.chat:type_name -> game.ChatMessage
-	6, // 3: game.GameMessage.login:type_name -> game.LoginMessage
-	3, // 4: game.GameMessage.spawnMyPlayer:type_name -> game.SpawnMyPlayer
-	4, // 5: game.GameMessage.spawnOtherPlayer:type_name -> game.SpawnOtherPlayer
-	7, // 6: game.GameMessage.logout:type_name -> game.LogoutMessage
-	1, // 7: game.GameMessage.pathTest:type_name -> game.PathTest
-	8, // 8: game.GameMessage.spawnMonster:type_name -> game.SpawnMonster
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	1,  // 0: game.PathTest.paths:type_name -> game.NavV3
+	0,  // 1: game.MonsterState.action:type_name -> game.MonsterAction
+	19, // 2: game.WorldSnapshot.players:type_name -> game.PlayerState
+	20, // 3: game.WorldSnapshot.monsters:type_name -> game.MonsterState
+	3,  // 4: game.GameMessage.player_position:type_name -> game.PlayerPosition
+	6,  // 5: game.GameMessage.chat:type_name -> game.ChatMessage
+	7,  // 6: game.GameMessage.login:type_name -> game.LoginMessage
+	4,  // 7: game.GameMessage.spawnMyPlayer:type_name -> game.SpawnMyPlayer
+	5,  // 8: game.GameMessage.spawnOtherPlayer:type_name -> game.SpawnOtherPlayer
+	10, // 9: game.GameMessage.logout:type_name -> game.LogoutMessage
+	2,  // 10: game.GameMessage.pathTest:type_name -> game.PathTest
+	11, // 11: game.GameMessage.spawnMonster:type_name -> game.SpawnMonster
+	12, // 12: game.GameMessage.serverShutdown:type_name -> game.ServerShutdown
+	13, // 13: game.GameMessage.ping:type_name -> game.Ping
+	14, // 14: game.GameMessage.pong:type_name -> game.Pong
+	8,  // 15: game.GameMessage.loginResult:type_name -> game.LoginResult
+	9,  // 16: game.GameMessage.kick:type_name -> game.KickMessage
+	15, // 17: game.GameMessage.despawnOtherPlayer:type_name -> game.DespawnOtherPlayer
+	16, // 18: game.GameMessage.despawnMonster:type_name -> game.DespawnMonster
+	18, // 19: game.GameMessage.positionCorrection:type_name -> game.PositionCorrection
+	23, // 20: game.GameMessage.worldSnapshot:type_name -> game.WorldSnapshot
+	24, // 21: game.GameMessage.snapshotAck:type_name -> game.SnapshotAck
+	17, // 22: game.GameMessage.attackMonster:type_name -> game.AttackMonster
+	21, // 23: game.GameMessage.monsterAttack:type_name -> game.MonsterAttack
+	22, // 24: game.GameMessage.monsterDied:type_name -> game.MonsterDied
+	25, // [25:25] is the sub-list for method output_type
+	25, // [25:25] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_GameMessage_proto_init() }
@@ -878,7 +2204,7 @@ func file_GameMessage_proto_init() {
 	if File_GameMessage_proto != nil {
 		return
 	}
-	file_GameMessage_proto_msgTypes[9].OneofWrappers = []any{
+	file_GameMessage_proto_msgTypes[24].OneofWrappers = []any{
 		(*GameMessage_PlayerPosition)(nil),
 		(*GameMessage_Chat)(nil),
 		(*GameMessage_Login)(nil),
@@ -887,19 +2213,33 @@ func file_GameMessage_proto_init() {
 		(*GameMessage_Logout)(nil),
 		(*GameMessage_PathTest)(nil),
 		(*GameMessage_SpawnMonster)(nil),
+		(*GameMessage_ServerShutdown)(nil),
+		(*GameMessage_Ping)(nil),
+		(*GameMessage_Pong)(nil),
+		(*GameMessage_LoginResult)(nil),
+		(*GameMessage_Kick)(nil),
+		(*GameMessage_DespawnOtherPlayer)(nil),
+		(*GameMessage_DespawnMonster)(nil),
+		(*GameMessage_PositionCorrection)(nil),
+		(*GameMessage_WorldSnapshot)(nil),
+		(*GameMessage_SnapshotAck)(nil),
+		(*GameMessage_AttackMonster)(nil),
+		(*GameMessage_MonsterAttack)(nil),
+		(*GameMessage_MonsterDied)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_GameMessage_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   10,
+			NumEnums:      1,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_GameMessage_proto_goTypes,
 		DependencyIndexes: file_GameMessage_proto_depIdxs,
+		EnumInfos:         file_GameMessage_proto_enumTypes,
 		MessageInfos:      file_GameMessage_proto_msgTypes,
 	}.Build()
 	File_GameMessage_proto = out.File
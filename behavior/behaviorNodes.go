@@ -1,9 +1,9 @@
 package behavior
 
 import (
-	"math"
 	"time"
-	manager "testServer/Manager"
+
+	"testServer/vecmath"
 )
 
 // 행동 트리의 상태를 나타내는 상수
@@ -15,127 +15,203 @@ const (
 	Running
 )
 
+// MarshalText lets traces and debug output show the status by name
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s Status) String() string {
+	switch s {
+	case Success:
+		return "success"
+	case Failure:
+		return "failure"
+	default:
+		return "running"
+	}
+}
+
 // Node 인터페이스 - 모든 노드가 구현해야 함
 type Node interface {
-	Execute() Status
+	Execute(ctx *Context) Status
 }
 
-// 몬스터 정보를 담는 구조체
+// 추적 대상 - 위치만 알 수 있으면 된다 (manager.Player가 구현)
+type Target interface {
+	Position() vecmath.Vec3
+}
+
+// 몬스터가 지금 하고 있는 행동 (매 틱 실행된 노드가 정한다)
+type Action int
+
+const (
+	ActionIdle Action = iota
+	ActionPatrol
+	ActionChase
+	ActionAttack
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionPatrol:
+		return "patrol"
+	case ActionChase:
+		return "chase"
+	case ActionAttack:
+		return "attack"
+	default:
+		return "idle"
+	}
+}
+
+// 몬스터 정보를 담는 구조체. 거리는 모두 X/Z 바닥 평면에서 잰다.
+// 대상이나 순찰 경로처럼 노드끼리 주고받는 상태는 트리의 Blackboard에 둔다.
 type Monster struct {
-	X, Z float32
-	HP   int
-	Target  *manager.Player
-	Path    []Point
-	PathIdx int
+	Pos       vecmath.Vec3
+	HP        int
 	MonsterId int32
-}
+	Action    Action
 
-// 위치 정보를 담는 구조체
-type Point struct {
-	X, Y float32
+	// 공격이 실제로 나갈 때 호출된다 (nil이면 무시)
+	OnAttack func(target Target, damage int)
 }
 
-// Sequence 노드 - 자식 노드들을 순차적으로 실행
+// Sequence 노드 - 자식 노드들을 순차적으로 실행. 매 틱 첫 자식부터 다시 확인하고,
+// 앞쪽 자식 때문에 도달하지 못한 실행 중인 자식은 중단시킨다.
 type Sequence struct {
-	children []Node
+	children []*slot
 }
 
 func NewSequence(children ...Node) *Sequence {
-	return &Sequence{children: children}
+	return &Sequence{children: newSlots(children)}
 }
 
-func (s *Sequence) Execute() Status {
-	for _, child := range s.children {
-		switch child.Execute() {
+func (s *Sequence) Execute(ctx *Context) Status {
+	for i, child := range s.children {
+		switch child.execute(ctx) {
 		case Failure:
+			abortExcept(s.children, i)
 			return Failure
 		case Running:
+			abortExcept(s.children, i)
 			return Running
 		}
 	}
 	return Success
 }
 
-// Selector 노드 - 자식 노드들 중 하나라도 성공할 때까지 실행
+func (s *Sequence) Reset() {
+	abortAll(s.children)
+}
+
+// Selector 노드 - 자식 노드들 중 하나라도 성공할 때까지 실행. 앞쪽 자식이 우선이므로
+// 앞쪽 자식이 끝나면 뒤쪽에서 실행 중이던 자식은 중단시킨다.
 type Selector struct {
-	children []Node
+	children []*slot
 }
 
 func NewSelector(children ...Node) *Selector {
-	return &Selector{children: children}
+	return &Selector{children: newSlots(children)}
 }
 
-func (s *Selector) Execute() Status {
-	for _, child := range s.children {
-		switch child.Execute() {
+func (s *Selector) Execute(ctx *Context) Status {
+	for i, child := range s.children {
+		switch child.execute(ctx) {
 		case Success:
+			abortExcept(s.children, i)
 			return Success
 		case Running:
+			abortExcept(s.children, i)
 			return Running
 		}
 	}
 	return Failure
 }
 
-// 순찰 행동을 담당하는 노드
+func (s *Selector) Reset() {
+	abortAll(s.children)
+}
+
+// 순찰 행동을 담당하는 노드. KeyPatrolPath의 지점 사이는 World가 찾은 경로를 따라
+// 초당 speed만큼 걷는다.
 type Patrol struct {
-	monster *Monster
+	monster  *Monster
+	speed    float32
+	follower pathFollower
 }
 
-func NewPatrol(monster *Monster) *Patrol {
-	return &Patrol{monster: monster}
+func NewPatrol(monster *Monster, speed float32) *Patrol {
+	return &Patrol{monster: monster, speed: speed}
 }
 
-func (p *Patrol) Execute() Status {
-	// 현재 목표 지점까지의 거리 계산
-	currentPoint := p.monster.Path[p.monster.PathIdx]
-	dist := distance(p.monster.X, p.monster.Z, currentPoint.X, currentPoint.Y)
+func (p *Patrol) Execute(ctx *Context) Status {
+	// 순찰 경로가 없으면 할 일이 없다
+	path, _ := Get(ctx.Blackboard, KeyPatrolPath)
+	if len(path) == 0 {
+		return Failure
+	}
+
+	p.monster.Action = ActionPatrol
+	idx, _ := Get(ctx.Blackboard, KeyPatrolIndex)
+	idx %= len(path)
+	currentPoint := path[idx]
+
+	// 현재 목표 지점까지의 경로를 찾는다. 갈 수 없는 지점은 건너뛴다.
+	if !p.follower.hasPath(p.monster) && !p.follower.findPath(ctx, p.monster, currentPoint) {
+		p.next(ctx, idx, len(path))
+		return Failure
+	}
 
 	// 목표 지점에 도달했으면 다음 지점으로
-	if dist < 1.0 {
-		p.monster.PathIdx = (p.monster.PathIdx + 1) % len(p.monster.Path)
+	if p.follower.step(p.monster, ctx.distance(p.speed)) {
+		p.next(ctx, idx, len(path))
 		return Success
 	}
+	return Running
+}
 
-	// 목표 지점을 향해 이동
-	speed := float32(2.0)
-	dx := currentPoint.X - p.monster.X
-	dy := currentPoint.Y - p.monster.Z
-	norm := float32(math.Sqrt(float64(dx*dx + dy*dy)))
-	p.monster.X += (dx / norm) * speed
-	p.monster.Z += (dy / norm) * speed
+// Reset drops the path to the current patrol point. The patrol index stays on the blackboard.
+func (p *Patrol) Reset() {
+	p.follower.clear()
+}
 
-	return Running
+func (p *Patrol) next(ctx *Context, idx, count int) {
+	Set(ctx.Blackboard, KeyPatrolIndex, (idx+1)%count)
+	p.follower.clear()
 }
 
-// 플레이어 감지를 담당하는 노드
+// 플레이어 감지를 담당하는 노드. 가장 가까운 대상을 KeyTarget에 두고, 그 대상이 범위 안에
+// 있으면 성공하면서 위치를 KeyLastSeen에 남긴다.
 type DetectPlayer struct {
-	monster *Monster
-	_range   float32
+	monster     *Monster
+	detectRange float32
 }
 
 func NewDetectPlayer(monster *Monster, detectRange float32) *DetectPlayer {
-	return &DetectPlayer{monster: monster, _range: detectRange}
+	return &DetectPlayer{monster: monster, detectRange: detectRange}
 }
 
-func (d *DetectPlayer) Execute() Status {
-	if d.monster.Target == nil {
+func (d *DetectPlayer) Execute(ctx *Context) Status {
+	target := ctx.nearestTarget(d.monster.Pos)
+	if target == nil {
+		Delete(ctx.Blackboard, KeyTarget)
 		return Failure
 	}
+	Set(ctx.Blackboard, KeyTarget, target)
 
-	dist := distance(d.monster.X, d.monster.Z, d.monster.Target.X, d.monster.Target.Y)
-	if dist <= d.range {
+	position := target.Position()
+	if d.monster.Pos.DistanceXZ(position) <= d.detectRange {
+		Set(ctx.Blackboard, KeyLastSeen, position)
 		return Success
 	}
 	return Failure
 }
 
-// 공격 행동을 담당하는 노드
+// 공격 행동을 담당하는 노드. KeyTarget을 공격하고, 쿨다운은 KeyLastAttack으로 잰다.
 type Attack struct {
 	monster     *Monster
 	attackRange float32
 	damage      int
-	lastAttack  time.Time
 	cooldown    time.Duration
 }
 
@@ -148,62 +224,82 @@ func NewAttack(monster *Monster, attackRange float32, damage int, cooldown time.
 	}
 }
 
-func (a *Attack) Execute() Status {
-	if a.monster.Target == nil {
+func (a *Attack) Execute(ctx *Context) Status {
+	target, ok := Get(ctx.Blackboard, KeyTarget)
+	if !ok || target == nil {
 		return Failure
 	}
 
 	// 공격 범위 확인
-	dist := distance(a.monster.X, a.monster.Z, a.monster.Target.X, a.monster.Target.Y)
-	if dist > a.attackRange {
+	if a.monster.Pos.DistanceXZ(target.Position()) > a.attackRange {
 		return Failure
 	}
 
-	// 쿨다운 확인
-	now := time.Now()
-	if now.Sub(a.lastAttack) < a.cooldown {
+	a.monster.Action = ActionAttack
+
+	// 쿨다운 확인. 한 번도 공격하지 않았으면 바로 공격한다.
+	if lastAttack, attacked := Get(ctx.Blackboard, KeyLastAttack); attacked && ctx.Now.Sub(lastAttack) < a.cooldown {
 		return Running
 	}
 
 	// 공격 실행
-	//a.monster.Target.HP -= a.damage
-	a.lastAttack = now
+	if a.monster.OnAttack != nil {
+		a.monster.OnAttack(target, a.damage)
+	}
+	Set(ctx.Blackboard, KeyLastAttack, ctx.Now)
 	return Success
 }
 
-// 추적 행동을 담당하는 노드
+// 추적 행동을 담당하는 노드. KeyTarget을 초당 speed만큼 쫓고, 목표가 repathDistance 이상
+// 움직이면 경로를 다시 찾는다.
 type Chase struct {
-	monster *Monster
-	speed   float32
+	monster        *Monster
+	speed          float32
+	repathDistance float32
+	follower       pathFollower
+	// 지금 경로를 찾았을 때의 목표 위치
+	goal vecmath.Vec3
 }
 
-func NewChase(monster *Monster, speed float32) *Chase {
-	return &Chase{monster: monster, speed: speed}
+func NewChase(monster *Monster, speed float32, repathDistance float32) *Chase {
+	if repathDistance <= 0 {
+		repathDistance = DefaultRepathDistance
+	}
+	return &Chase{
+		monster:        monster,
+		speed:          speed,
+		repathDistance: repathDistance,
+	}
 }
 
-func (c *Chase) Execute() Status {
-	if c.monster.Target == nil {
+func (c *Chase) Execute(ctx *Context) Status {
+	targetEntity, ok := Get(ctx.Blackboard, KeyTarget)
+	if !ok || targetEntity == nil {
 		return Failure
 	}
 
-	// 목표를 향해 이동
-	dx := c.monster.Target.X - c.monster.X
-	dy := c.monster.Target.Y - c.monster.Z
-	norm := float32(math.Sqrt(float64(dx*dx + dy*dy)))
+	c.monster.Action = ActionChase
 
 	// 이미 충분히 가까우면 성공
-	if norm < 1.0 {
+	target := targetEntity.Position()
+	if c.monster.Pos.DistanceXZ(target) < 1.0 {
 		return Success
 	}
 
-	c.monster.X += (dx / norm) * c.speed
-	c.monster.Z += (dy / norm) * c.speed
+	// 경로가 없거나 목표가 멀리 움직였으면 새로 찾고, 길이 없으면 추적을 포기한다
+	if !c.follower.hasPath(c.monster) || c.goal.DistanceXZ(target) > c.repathDistance {
+		if !c.follower.findPath(ctx, c.monster, target) {
+			return Failure
+		}
+		c.goal = target
+	}
+
+	// 목표를 향해 이동
+	c.follower.step(c.monster, ctx.distance(c.speed))
 	return Running
 }
 
-// 거리 계산 유틸리티 함수
-func distance(x1, y1, x2, y2 float32) float32 {
-	dx := x2 - x1
-	dy := y2 - y1
-	return float32(math.Sqrt(float64(dx*dx + dy*dy)))
+// Reset drops the current path so the next chase finds a new one
+func (c *Chase) Reset() {
+	c.follower.clear()
 }
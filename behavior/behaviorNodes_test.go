@@ -0,0 +1,117 @@
+package behavior
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"testServer/vecmath"
+)
+
+type fixedTarget vecmath.Vec3
+
+func (t fixedTarget) Position() vecmath.Vec3 {
+	return vecmath.Vec3(t)
+}
+
+// fakeWorld는 대상 하나와 직선 경로만 있는 세계다
+type fakeWorld struct {
+	target Target
+}
+
+func (w fakeWorld) FindPath(from, to vecmath.Vec3) ([]vecmath.Vec3, error) {
+	return []vecmath.Vec3{to}, nil
+}
+
+func (w fakeWorld) NearestTarget(from vecmath.Vec3) Target {
+	return w.target
+}
+
+// 거리는 X/Z 바닥 평면에서 재고 높이(Y)는 무시해야 한다
+func TestRangeChecksUseGroundPlane(t *testing.T) {
+	tests := []struct {
+		name   string
+		target vecmath.Vec3
+		want   Status
+	}{
+		{"in range along z", vecmath.New(0, 0, 4), Success},
+		{"in range along x", vecmath.New(4, 0, 0), Success},
+		{"height is ignored", vecmath.New(0, 100, 4), Success},
+		{"out of range along z", vecmath.New(0, 0, 6), Failure},
+		{"y is not z", vecmath.New(0, 4, 6), Failure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monster := &Monster{}
+			ctx := &Context{World: fakeWorld{target: fixedTarget(tt.target)}, Blackboard: NewBlackboard()}
+			if got := NewDetectPlayer(monster, 5).Execute(ctx); got != tt.want {
+				t.Errorf("DetectPlayer = %v, want %v", got, tt.want)
+			}
+			if got := NewAttack(monster, 5, 1, time.Second).Execute(ctx); got != tt.want {
+				t.Errorf("Attack = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChaseMovesOnGroundPlane(t *testing.T) {
+	monster := &Monster{}
+	ctx := &Context{DT: time.Second, Blackboard: NewBlackboard()}
+	Set[Target](ctx.Blackboard, KeyTarget, fixedTarget(vecmath.New(0, 50, 10)))
+	chase := NewChase(monster, 2, 0)
+
+	if got := chase.Execute(ctx); got != Running {
+		t.Fatalf("Chase = %v, want Running", got)
+	}
+	// 1초 동안 바닥 거리로 초당 speed만큼 Z축을 따라 다가가야 한다
+	if got := monster.Pos.XZ(); got != vecmath.New(0, 0, 2) {
+		t.Fatalf("monster moved to %v, want (0, 0, 2) on the ground plane", got)
+	}
+}
+
+// 시계를 직접 돌리면 벽시계나 호출 빈도와 상관없이 같은 결과가 나와야 한다
+func TestTreeRunsOnSimulatedTime(t *testing.T) {
+	params := MonsterParams{
+		DetectRange:    20,
+		AttackRange:    2.25,
+		AttackDamage:   5,
+		AttackCooldown: time.Second,
+		ChaseSpeed:     4,
+	}
+	const dt = 125 * time.Millisecond
+
+	run := func(ticks int) (attacks []int, pos vecmath.Vec3) {
+		monster := &Monster{}
+		tree, err := NewTree("monster1", monster, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tick := 0
+		monster.OnAttack = func(target Target, damage int) {
+			attacks = append(attacks, tick)
+		}
+
+		world := fakeWorld{target: fixedTarget(vecmath.New(0, 0, 10))}
+		now := time.Unix(0, 0)
+		for ; tick < ticks; tick++ {
+			tree.Tick(world, now, dt)
+			now = now.Add(dt)
+		}
+		return attacks, monster.Pos
+	}
+
+	// 초당 4씩 다가가면 2초(16틱) 뒤 공격 범위에 들고, 그 뒤로는 쿨다운 1초(8틱)마다 공격한다
+	attacks, pos := run(33)
+	if want := []int{16, 24, 32}; !slices.Equal(attacks, want) {
+		t.Fatalf("attacked on ticks %v, want %v", attacks, want)
+	}
+	if distance := pos.DistanceXZ(vecmath.New(0, 0, 10)); distance > params.AttackRange || distance < 1.5 {
+		t.Fatalf("monster stopped %v from its target", distance)
+	}
+
+	again, againPos := run(33)
+	if !slices.Equal(again, attacks) || againPos != pos {
+		t.Fatalf("second run attacked on %v at %v, first on %v at %v", again, againPos, attacks, pos)
+	}
+}
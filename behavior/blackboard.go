@@ -0,0 +1,99 @@
+package behavior
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"testServer/vecmath"
+)
+
+// Key names a blackboard entry holding a T. Declare keys once as package variables so
+// every node reading or writing the entry agrees on its type.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey returns the key called name
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{name: name}
+}
+
+func (k Key[T]) String() string {
+	return k.name
+}
+
+// 몬스터 트리가 쓰는 공용 키
+var (
+	// 가장 가까운 대상. 범위 밖이어도 DetectPlayer가 매번 정하고, 대상이 없으면 지운다.
+	KeyTarget = NewKey[Target]("target")
+	// 대상을 마지막으로 감지한 위치
+	KeyLastSeen = NewKey[vecmath.Vec3]("last_seen")
+	// 스폰된 위치
+	KeyHome = NewKey[vecmath.Vec3]("home")
+	// 순찰 경로와 지금 향하는 순찰 지점
+	KeyPatrolPath  = NewKey[[]vecmath.Vec3]("patrol_path")
+	KeyPatrolIndex = NewKey[int]("patrol_index")
+	// 마지막으로 공격한 시뮬레이션 시각 (Context.Now)
+	KeyLastAttack = NewKey[time.Time]("last_attack")
+)
+
+// Blackboard is the memory shared by the nodes of one tree instance. Every tree built
+// by NewTree gets its own, so nothing leaks between monsters or across respawns.
+type Blackboard struct {
+	mu     sync.RWMutex
+	values map[string]any
+}
+
+func NewBlackboard() *Blackboard {
+	return &Blackboard{values: make(map[string]any)}
+}
+
+// Get returns the value stored under key. It reports false when the entry is missing
+// or holds a value of another type.
+func Get[T any](bb *Blackboard, key Key[T]) (T, bool) {
+	bb.mu.RLock()
+	defer bb.mu.RUnlock()
+	value, ok := bb.values[key.name].(T)
+	return value, ok
+}
+
+// Set stores value under key
+func Set[T any](bb *Blackboard, key Key[T], value T) {
+	bb.mu.Lock()
+	defer bb.mu.Unlock()
+	bb.values[key.name] = value
+}
+
+// Delete removes the entry under key
+func Delete[T any](bb *Blackboard, key Key[T]) {
+	bb.mu.Lock()
+	defer bb.mu.Unlock()
+	delete(bb.values, key.name)
+}
+
+// Clear removes every entry
+func (bb *Blackboard) Clear() {
+	bb.mu.Lock()
+	defer bb.mu.Unlock()
+	clear(bb.values)
+}
+
+// Inspect returns every entry formatted with fmt for debugging
+func (bb *Blackboard) Inspect() map[string]string {
+	bb.mu.RLock()
+	values := maps.Clone(bb.values)
+	bb.mu.RUnlock()
+
+	entries := make(map[string]string, len(values))
+	for name, value := range values {
+		switch value := value.(type) {
+		case time.Time:
+			entries[name] = value.Format(time.RFC3339Nano)
+		default:
+			entries[name] = fmt.Sprint(value)
+		}
+	}
+	return entries
+}
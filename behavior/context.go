@@ -0,0 +1,50 @@
+package behavior
+
+import (
+	"time"
+
+	"testServer/vecmath"
+)
+
+// World is what nodes may ask about the world around their monster
+type World interface {
+	PathFinder
+	// NearestTarget returns the target closest to from on the X/Z plane, or nil when there is none
+	NearestTarget(from vecmath.Vec3) Target
+}
+
+// Context is passed to every node executed during one tick of a tree. Nodes read time
+// from it rather than the wall clock, so a tree run with a fake clock is deterministic.
+type Context struct {
+	// 지난 틱 이후 흐른 시뮬레이션 시간
+	DT time.Duration
+	// 이번 틱의 시뮬레이션 시각
+	Now time.Time
+	// nil이면 대상이 없고 경로는 직선인 빈 세계로 본다
+	World      World
+	Blackboard *Blackboard
+
+	// 트리에 Tracer가 있을 때만 설정된다
+	tracer *Tracer
+}
+
+// findPath asks the world for a path, or returns a straight line without one
+func (ctx *Context) findPath(from, to vecmath.Vec3) ([]vecmath.Vec3, error) {
+	if ctx.World == nil {
+		return []vecmath.Vec3{to}, nil
+	}
+	return ctx.World.FindPath(from, to)
+}
+
+// nearestTarget returns the target closest to from, or nil
+func (ctx *Context) nearestTarget(from vecmath.Vec3) Target {
+	if ctx.World == nil {
+		return nil
+	}
+	return ctx.World.NearestTarget(from)
+}
+
+// distance returns how far something moving at speed units per second gets in this tick
+func (ctx *Context) distance(speed float32) float32 {
+	return speed * float32(ctx.DT.Seconds())
+}
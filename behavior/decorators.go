@@ -0,0 +1,195 @@
+package behavior
+
+import "time"
+
+// Inverter 노드 - 자식의 성공과 실패를 뒤집는다
+type Inverter struct {
+	child *slot
+}
+
+func NewInverter(child Node) *Inverter {
+	return &Inverter{child: newSlot(child)}
+}
+
+func (i *Inverter) Execute(ctx *Context) Status {
+	switch i.child.execute(ctx) {
+	case Success:
+		return Failure
+	case Failure:
+		return Success
+	}
+	return Running
+}
+
+func (i *Inverter) Reset() {
+	i.child.abort()
+}
+
+// Succeeder 노드 - 자식이 끝나면 결과와 상관없이 성공한다
+type Succeeder struct {
+	child *slot
+}
+
+func NewSucceeder(child Node) *Succeeder {
+	return &Succeeder{child: newSlot(child)}
+}
+
+func (s *Succeeder) Execute(ctx *Context) Status {
+	if s.child.execute(ctx) == Running {
+		return Running
+	}
+	return Success
+}
+
+func (s *Succeeder) Reset() {
+	s.child.abort()
+}
+
+// Repeater 노드 - 자식이 count번 성공할 때까지 틱마다 한 번씩 실행한다.
+// 자식이 실패하면 실패하고, count가 0 이하면 끝없이 반복한다.
+type Repeater struct {
+	child     *slot
+	count     int
+	successes int
+}
+
+func NewRepeater(child Node, count int) *Repeater {
+	return &Repeater{child: newSlot(child), count: count}
+}
+
+func (r *Repeater) Execute(ctx *Context) Status {
+	switch r.child.execute(ctx) {
+	case Failure:
+		r.successes = 0
+		return Failure
+	case Success:
+		r.successes++
+		if r.count > 0 && r.successes >= r.count {
+			r.successes = 0
+			return Success
+		}
+	}
+	return Running
+}
+
+func (r *Repeater) Reset() {
+	r.child.abort()
+	r.successes = 0
+}
+
+// RetryUntilSuccess 노드 - 자식이 성공할 때까지 틱마다 다시 실행한다.
+// attempts번 실패하면 실패하고, attempts가 0 이하면 끝없이 다시 시도한다.
+type RetryUntilSuccess struct {
+	child    *slot
+	attempts int
+	failures int
+}
+
+func NewRetryUntilSuccess(child Node, attempts int) *RetryUntilSuccess {
+	return &RetryUntilSuccess{child: newSlot(child), attempts: attempts}
+}
+
+func (r *RetryUntilSuccess) Execute(ctx *Context) Status {
+	switch r.child.execute(ctx) {
+	case Success:
+		r.failures = 0
+		return Success
+	case Failure:
+		r.failures++
+		if r.attempts > 0 && r.failures >= r.attempts {
+			r.failures = 0
+			return Failure
+		}
+	}
+	return Running
+}
+
+func (r *RetryUntilSuccess) Reset() {
+	r.child.abort()
+	r.failures = 0
+}
+
+// Timeout 노드 - 자식이 timeout 안에 끝나지 않으면 실패한다
+type Timeout struct {
+	child   *slot
+	timeout time.Duration
+	// 자식이 Running을 돌려주기 시작한 시각
+	running bool
+	started time.Time
+}
+
+func NewTimeout(child Node, timeout time.Duration) *Timeout {
+	return &Timeout{child: newSlot(child), timeout: timeout}
+}
+
+func (t *Timeout) Execute(ctx *Context) Status {
+	if !t.running {
+		t.running, t.started = true, ctx.Now
+	} else if ctx.Now.Sub(t.started) >= t.timeout {
+		t.Reset()
+		return Failure
+	}
+
+	status := t.child.execute(ctx)
+	if status != Running {
+		t.running = false
+	}
+	return status
+}
+
+func (t *Timeout) Reset() {
+	t.child.abort()
+	t.running = false
+}
+
+// Cooldown 노드 - 자식이 끝난 뒤 cooldown 동안은 자식을 실행하지 않고 실패한다
+type Cooldown struct {
+	child    *slot
+	cooldown time.Duration
+	// 자식이 한 번이라도 끝났으면 그 시각
+	done     bool
+	finished time.Time
+}
+
+func NewCooldown(child Node, cooldown time.Duration) *Cooldown {
+	return &Cooldown{child: newSlot(child), cooldown: cooldown}
+}
+
+func (c *Cooldown) Execute(ctx *Context) Status {
+	if c.done && ctx.Now.Sub(c.finished) < c.cooldown {
+		return Failure
+	}
+
+	status := c.child.execute(ctx)
+	if status != Running {
+		c.done, c.finished = true, ctx.Now
+	}
+	return status
+}
+
+// Reset aborts the child. The cooldown keeps running so resetting can't skip it.
+func (c *Cooldown) Reset() {
+	c.child.abort()
+}
+
+// Condition 노드 - check가 참일 때만 자식을 실행한다. 거짓이면 실행 중인 자식을 중단시키고 실패한다.
+type Condition struct {
+	check func(ctx *Context) bool
+	child *slot
+}
+
+func NewCondition(check func(ctx *Context) bool, child Node) *Condition {
+	return &Condition{check: check, child: newSlot(child)}
+}
+
+func (c *Condition) Execute(ctx *Context) Status {
+	if !c.check(ctx) {
+		c.child.abort()
+		return Failure
+	}
+	return c.child.execute(ctx)
+}
+
+func (c *Condition) Reset() {
+	c.child.abort()
+}
@@ -0,0 +1,262 @@
+package behavior
+
+import (
+	"testing"
+	"time"
+)
+
+// scripted는 정해 둔 결과를 차례로 돌려주는 노드다. 결과가 다 떨어지면 마지막 결과를 반복한다.
+type scripted struct {
+	results []Status
+	calls   int
+}
+
+func script(results ...Status) *scripted {
+	return &scripted{results: results}
+}
+
+func (s *scripted) Execute(ctx *Context) Status {
+	status := s.results[min(s.calls, len(s.results)-1)]
+	s.calls++
+	return status
+}
+
+func TestDecorators(t *testing.T) {
+	tests := []struct {
+		name string
+		// node는 자식으로 쓸 노드를 받아 테스트할 노드를 만든다
+		node  func(child Node) Node
+		child []Status
+		// 틱 사이에 흐르는 시간
+		step time.Duration
+		want []Status
+		// 자식이 실행된 횟수
+		wantCalls int
+	}{
+		{
+			name:      "inverter flips results",
+			node:      func(c Node) Node { return NewInverter(c) },
+			child:     []Status{Success, Failure, Running},
+			want:      []Status{Failure, Success, Running},
+			wantCalls: 3,
+		},
+		{
+			name:      "succeeder hides failure",
+			node:      func(c Node) Node { return NewSucceeder(c) },
+			child:     []Status{Failure, Running, Success},
+			want:      []Status{Success, Running, Success},
+			wantCalls: 3,
+		},
+		{
+			name:      "repeater succeeds after count successes",
+			node:      func(c Node) Node { return NewRepeater(c, 3) },
+			child:     []Status{Success, Running, Success, Success, Success},
+			want:      []Status{Running, Running, Running, Success, Running},
+			wantCalls: 5,
+		},
+		{
+			name:      "repeater fails with its child",
+			node:      func(c Node) Node { return NewRepeater(c, 3) },
+			child:     []Status{Success, Failure},
+			want:      []Status{Running, Failure},
+			wantCalls: 2,
+		},
+		{
+			name:      "repeater without count never finishes",
+			node:      func(c Node) Node { return NewRepeater(c, 0) },
+			child:     []Status{Success},
+			want:      []Status{Running, Running, Running},
+			wantCalls: 3,
+		},
+		{
+			name:      "retry succeeds once the child does",
+			node:      func(c Node) Node { return NewRetryUntilSuccess(c, 3) },
+			child:     []Status{Failure, Failure, Success},
+			want:      []Status{Running, Running, Success},
+			wantCalls: 3,
+		},
+		{
+			name:      "retry gives up after attempts",
+			node:      func(c Node) Node { return NewRetryUntilSuccess(c, 2) },
+			child:     []Status{Failure},
+			want:      []Status{Running, Failure, Running, Failure},
+			wantCalls: 4,
+		},
+		{
+			name:      "timeout passes results through in time",
+			node:      func(c Node) Node { return NewTimeout(c, time.Second) },
+			child:     []Status{Running, Success},
+			step:      400 * time.Millisecond,
+			want:      []Status{Running, Success},
+			wantCalls: 2,
+		},
+		{
+			name:      "timeout fails a child that runs too long",
+			node:      func(c Node) Node { return NewTimeout(c, time.Second) },
+			child:     []Status{Running},
+			step:      400 * time.Millisecond,
+			want:      []Status{Running, Running, Running, Failure, Running},
+			wantCalls: 4,
+		},
+		{
+			name:      "cooldown skips the child after it finishes",
+			node:      func(c Node) Node { return NewCooldown(c, time.Second) },
+			child:     []Status{Success},
+			step:      400 * time.Millisecond,
+			want:      []Status{Success, Failure, Failure, Success},
+			wantCalls: 2,
+		},
+		{
+			name:      "cooldown waits while the child runs",
+			node:      func(c Node) Node { return NewCooldown(c, time.Second) },
+			child:     []Status{Running, Running, Failure, Success},
+			step:      time.Second,
+			want:      []Status{Running, Running, Failure, Success},
+			wantCalls: 4,
+		},
+		{
+			name:      "condition runs the child when true",
+			node:      func(c Node) Node { return NewCondition(func(*Context) bool { return true }, c) },
+			child:     []Status{Running, Success},
+			want:      []Status{Running, Success},
+			wantCalls: 2,
+		},
+		{
+			name:      "condition fails without running the child when false",
+			node:      func(c Node) Node { return NewCondition(func(*Context) bool { return false }, c) },
+			child:     []Status{Success},
+			want:      []Status{Failure, Failure},
+			wantCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &Context{Now: time.Unix(1000, 0), DT: tt.step}
+			child := script(tt.child...)
+			node := tt.node(child)
+			for i, want := range tt.want {
+				if got := node.Execute(ctx); got != want {
+					t.Fatalf("tick %d: got %v, want %v", i, got, want)
+				}
+				ctx.Now = ctx.Now.Add(tt.step)
+			}
+			if child.calls != tt.wantCalls {
+				t.Fatalf("child ran %d times, want %d", child.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestParallel(t *testing.T) {
+	tests := []struct {
+		name             string
+		success, failure ParallelPolicy
+		children         [][]Status
+		want             []Status
+		// 자식마다 실행된 횟수 (비어 있으면 확인하지 않는다)
+		wantCalls []int
+	}{
+		{
+			name:     "require one success",
+			success:  RequireOne,
+			failure:  RequireAll,
+			children: [][]Status{{Running, Success}, {Running}},
+			want:     []Status{Running, Success},
+		},
+		{
+			name:     "require all successes",
+			success:  RequireAll,
+			failure:  RequireOne,
+			children: [][]Status{{Success}, {Running, Running, Success}},
+			want:     []Status{Running, Running, Success},
+		},
+		{
+			name:     "one failure fails",
+			success:  RequireAll,
+			failure:  RequireOne,
+			children: [][]Status{{Success}, {Running, Failure}},
+			want:     []Status{Running, Failure},
+		},
+		{
+			name:     "failure is checked before success",
+			success:  RequireOne,
+			failure:  RequireOne,
+			children: [][]Status{{Success}, {Failure}},
+			want:     []Status{Failure},
+		},
+		{
+			name:     "require all failures",
+			success:  RequireAll,
+			failure:  RequireAll,
+			children: [][]Status{{Failure}, {Running, Failure}},
+			want:     []Status{Running, Failure},
+		},
+		{
+			name:      "successes in different ticks are kept",
+			success:   RequireAll,
+			failure:   RequireOne,
+			children:  [][]Status{{Success, Running}, {Running, Success}},
+			want:      []Status{Running, Success},
+			wantCalls: []int{1, 2},
+		},
+		{
+			name:      "failures in different ticks are kept",
+			success:   RequireAll,
+			failure:   RequireAll,
+			children:  [][]Status{{Failure, Running}, {Running, Running, Failure}},
+			want:      []Status{Running, Running, Failure},
+			wantCalls: []int{1, 3},
+		},
+		{
+			name:     "finished without meeting a policy fails",
+			success:  RequireAll,
+			failure:  RequireAll,
+			children: [][]Status{{Success}, {Failure}},
+			want:     []Status{Failure},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			children := make([]Node, 0, len(tt.children))
+			scripts := make([]*scripted, 0, len(tt.children))
+			for _, results := range tt.children {
+				child := script(results...)
+				children = append(children, child)
+				scripts = append(scripts, child)
+			}
+			node := NewParallel(tt.success, tt.failure, children...)
+			for i, want := range tt.want {
+				if got := node.Execute(&Context{}); got != want {
+					t.Fatalf("tick %d: got %v, want %v", i, got, want)
+				}
+			}
+			for i, want := range tt.wantCalls {
+				if calls := scripts[i].calls; calls != want {
+					t.Fatalf("child %d ran %d times, want %d", i, calls, want)
+				}
+			}
+		})
+	}
+}
+
+// 끝났거나 중단된 Parallel은 저장한 결과를 버리고 모든 자식을 다시 실행한다
+func TestParallelStartsOver(t *testing.T) {
+	first, second := script(Success), script(Running, Success)
+	node := NewParallel(RequireAll, RequireOne, first, second)
+
+	node.Execute(&Context{})
+	node.Reset()
+	if got := node.Execute(&Context{}); got != Success {
+		t.Fatalf("after reset: got %v, want %v", got, Success)
+	}
+	if first.calls != 2 {
+		t.Fatalf("finished child ran %d times across the reset, want 2", first.calls)
+	}
+
+	node.Execute(&Context{})
+	if first.calls != 3 || second.calls != 3 {
+		t.Fatalf("children ran %d and %d times after the parallel finished, want 3 and 3", first.calls, second.calls)
+	}
+}
@@ -0,0 +1,91 @@
+package behavior
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// NodeDefinition is one node of a tree definition. Type names a registered node type
+// (see RegisterNode), and Params holds its settings as literals or "$stat" references.
+type NodeDefinition struct {
+	Type     string                     `json:"type"`
+	Params   map[string]json.RawMessage `json:"params,omitempty"`
+	Children []*NodeDefinition          `json:"children,omitempty"`
+}
+
+// TreeFile is a file of named tree definitions. Trees defined in the file that is in
+// use take precedence over trees of the same name registered from code.
+type TreeFile struct {
+	Trees map[string]*NodeDefinition `json:"trees"`
+}
+
+// 지금 쓰고 있는 트리 정의 파일. 핫 리로드 때 통째로 바뀐다.
+var definitions struct {
+	mu   sync.RWMutex
+	file *TreeFile
+}
+
+// LoadTreeFile reads the tree definitions at path
+func LoadTreeFile(path string) (*TreeFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open behavior trees: %w", err)
+	}
+	defer file.Close()
+
+	var trees TreeFile
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&trees); err != nil {
+		return nil, fmt.Errorf("decode behavior trees %s: %w", path, err)
+	}
+	return &trees, nil
+}
+
+// Validate builds every tree once and reports every problem at once. Values of "$stat"
+// params depend on the template, so CheckTree checks them per template.
+func (f *TreeFile) Validate() error {
+	names := make([]string, 0, len(f.Trees))
+	for name := range f.Trees {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if name == "" {
+			errs = append(errs, errors.New("trees: name must not be empty"))
+			continue
+		}
+		_, treeErrs := buildNode(f.Trees[name], "root", &Monster{}, nil)
+		for _, err := range treeErrs {
+			errs = append(errs, fmt.Errorf("tree %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UseTreeFile makes the trees in f available to NewTree and returns the file used before,
+// or nil. Trees already built are not changed (see Tree.Reload). f must be validated first.
+func UseTreeFile(f *TreeFile) *TreeFile {
+	definitions.mu.Lock()
+	defer definitions.mu.Unlock()
+	previous := definitions.file
+	definitions.file = f
+	return previous
+}
+
+// definition returns the definition of the tree called name in the file in use
+func definition(name string) (*NodeDefinition, bool) {
+	definitions.mu.RLock()
+	defer definitions.mu.RUnlock()
+	if definitions.file == nil {
+		return nil, false
+	}
+	def, exists := definitions.file.Trees[name]
+	return def, exists
+}
@@ -0,0 +1,146 @@
+package behavior
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExampleTreeFileIsValid(t *testing.T) {
+	trees, err := LoadTreeFile("../behaviors.example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := trees.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	tests := []struct {
+		name string
+		tree string
+		want []string
+	}{
+		{
+			name: "unknown node type",
+			tree: `{"type": "selector", "children": [{"type": "chse"}]}`,
+			want: []string{`root (selector).children[0]: unknown node type "chse"`},
+		},
+		{
+			name: "wrong child count",
+			tree: `{"type": "inverter", "children": [{"type": "patrol"}, {"type": "chase"}]}`,
+			want: []string{"root (inverter): has 2 children, want 1"},
+		},
+		{
+			name: "leaf with children",
+			tree: `{"type": "attack", "children": [{"type": "patrol"}]}`,
+			want: []string{"root (attack): has 1 children, want 0"},
+		},
+		{
+			name: "bad params",
+			tree: `{"type": "sequence", "children": [
+				{"type": "chase", "params": {"speed": "fast"}},
+				{"type": "attack", "params": {"damage": "$speed"}},
+				{"type": "patrol", "params": {"sped": 2}},
+				{"type": "timeout", "params": {"duration": "0s"}, "children": [{"type": "patrol"}]}
+			]}`,
+			want: []string{
+				`children[0] (chase): param "speed" must be a number`,
+				`children[1] (attack): param "damage": unknown stat "$speed"`,
+				`children[2] (patrol): unknown param "sped"`,
+				`children[3] (timeout): param "duration" must be positive`,
+			},
+		},
+		{
+			name: "unknown check and policy",
+			tree: `{"type": "parallel", "params": {"success": "most"}, "children": [
+				{"type": "condition", "params": {"check": "is_angry"}, "children": [{"type": "attack"}]}
+			]}`,
+			want: []string{
+				`root (parallel): param "success" must be "one" or "all", got "most"`,
+				`children[0] (condition): unknown check "is_angry"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trees := &TreeFile{Trees: map[string]*NodeDefinition{}}
+			var def NodeDefinition
+			if err := json.Unmarshal([]byte(tt.tree), &def); err != nil {
+				t.Fatal(err)
+			}
+			trees.Trees["broken"] = &def
+
+			err := trees.Validate()
+			if err == nil {
+				t.Fatal("Validate accepted an invalid tree")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error does not mention %q:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestTreeFileShadowsCodeAndReloads(t *testing.T) {
+	t.Cleanup(func() { UseTreeFile(nil) })
+	define := func(tree string) {
+		var def NodeDefinition
+		if err := json.Unmarshal([]byte(tree), &def); err != nil {
+			t.Fatal(err)
+		}
+		UseTreeFile(&TreeFile{Trees: map[string]*NodeDefinition{"monster1": &def}})
+	}
+
+	// 파일의 monster1은 순찰만 한다. 대상이 있어도 쫓지 않는다.
+	define(`{"type": "patrol"}`)
+	monster := &Monster{}
+	tree, err := NewTree("monster1", monster, MonsterParams{DetectRange: 10, ChaseSpeed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	Set[Target](tree.Blackboard, KeyTarget, fixedTarget{Z: 5})
+	if got := tree.Tick(nil, time.Time{}, 0); got != Failure {
+		t.Fatalf("patrol-only tree = %v, want Failure without a patrol path", got)
+	}
+
+	// 정의를 바꾸고 다시 만들면 블랙보드는 그대로 두고 새 정의로 움직인다
+	define(`{"type": "chase"}`)
+	if err := tree.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if got := tree.Tick(nil, time.Time{}, 0); got != Running || monster.Action != ActionChase {
+		t.Fatalf("reloaded tree = %v with action %v, want a running chase", got, monster.Action)
+	}
+}
+
+// "$stat" params의 값은 템플릿을 알아야 검사할 수 있다
+func TestStatParamsAreCheckedPerTemplate(t *testing.T) {
+	t.Cleanup(func() { UseTreeFile(nil) })
+	var def NodeDefinition
+	tree := `{"type": "cooldown", "params": {"duration": "$attack_cooldown"}, "children": [{"type": "attack"}]}`
+	if err := json.Unmarshal([]byte(tree), &def); err != nil {
+		t.Fatal(err)
+	}
+	trees := &TreeFile{Trees: map[string]*NodeDefinition{"guarded": &def}}
+	if err := trees.Validate(); err != nil {
+		t.Fatalf("Validate rejected a $stat duration: %v", err)
+	}
+	UseTreeFile(trees)
+
+	if err := CheckTree("guarded", MonsterParams{AttackCooldown: time.Second}); err != nil {
+		t.Fatalf("CheckTree with a 1s cooldown: %v", err)
+	}
+	err := CheckTree("guarded", MonsterParams{})
+	if err == nil || !strings.Contains(err.Error(), `param "duration" must be positive`) {
+		t.Fatalf("CheckTree without a cooldown = %v, want the duration rejected", err)
+	}
+	if _, err := NewTree("guarded", &Monster{}, MonsterParams{}); err == nil {
+		t.Fatal("NewTree built a tree CheckTree rejects")
+	}
+}
@@ -0,0 +1,83 @@
+package behavior
+
+// Resetter is implemented by nodes that keep state between ticks. Reset drops that state
+// so the next Execute starts over. Composites and decorators abort their running children.
+type Resetter interface {
+	Reset()
+}
+
+// EnterHook is implemented by nodes that want to know when a run starts, before its first Execute
+type EnterHook interface {
+	OnEnter()
+}
+
+// ExitHook is implemented by nodes that want to know when a run ends. status is the final
+// status, or Running when the run was aborted before it finished.
+type ExitHook interface {
+	OnExit(status Status)
+}
+
+// slot holds a child of a composite or decorator and tracks whether it is in the middle of a
+// run, so it can call the hooks and abort it when its parent moves on
+type slot struct {
+	node    Node
+	running bool
+}
+
+func newSlot(node Node) *slot {
+	return &slot{node: node}
+}
+
+func newSlots(nodes []Node) []*slot {
+	slots := make([]*slot, 0, len(nodes))
+	for _, node := range nodes {
+		slots = append(slots, newSlot(node))
+	}
+	return slots
+}
+
+func (s *slot) execute(ctx *Context) Status {
+	if !s.running {
+		if hook, ok := s.node.(EnterHook); ok {
+			hook.OnEnter()
+		}
+	}
+
+	index := ctx.tracer.enter(s.node)
+	status := s.node.Execute(ctx)
+	ctx.tracer.exit(index, status)
+	s.running = status == Running
+	if !s.running {
+		if hook, ok := s.node.(ExitHook); ok {
+			hook.OnExit(status)
+		}
+	}
+	return status
+}
+
+// abort interrupts the child if it is running. Finished children are left alone.
+func (s *slot) abort() {
+	if !s.running {
+		return
+	}
+	s.running = false
+	if resetter, ok := s.node.(Resetter); ok {
+		resetter.Reset()
+	}
+	if hook, ok := s.node.(ExitHook); ok {
+		hook.OnExit(Running)
+	}
+}
+
+// abortExcept aborts every running slot but slots[keep]
+func abortExcept(slots []*slot, keep int) {
+	for i, s := range slots {
+		if i != keep {
+			s.abort()
+		}
+	}
+}
+
+func abortAll(slots []*slot) {
+	abortExcept(slots, -1)
+}
@@ -0,0 +1,63 @@
+package behavior
+
+// MemorySequence 노드 - Sequence와 같지만 실행 중인 자식을 기억했다가 다음 틱에 그 자식부터
+// 이어서 실행한다. 앞쪽 자식은 다시 확인하지 않으므로 여러 틱에 걸친 행동을 차례로 끝낼 수 있다.
+type MemorySequence struct {
+	children []*slot
+	current  int
+}
+
+func NewMemorySequence(children ...Node) *MemorySequence {
+	return &MemorySequence{children: newSlots(children)}
+}
+
+func (s *MemorySequence) Execute(ctx *Context) Status {
+	for s.current < len(s.children) {
+		switch s.children[s.current].execute(ctx) {
+		case Failure:
+			s.current = 0
+			return Failure
+		case Running:
+			return Running
+		}
+		s.current++
+	}
+	s.current = 0
+	return Success
+}
+
+func (s *MemorySequence) Reset() {
+	abortAll(s.children)
+	s.current = 0
+}
+
+// MemorySelector 노드 - Selector와 같지만 실행 중인 자식을 기억했다가 다음 틱에 그 자식부터
+// 이어서 실행한다. 앞쪽 자식이 다시 성공할 수 있어도 실행 중인 자식을 끊지 않는다.
+type MemorySelector struct {
+	children []*slot
+	current  int
+}
+
+func NewMemorySelector(children ...Node) *MemorySelector {
+	return &MemorySelector{children: newSlots(children)}
+}
+
+func (s *MemorySelector) Execute(ctx *Context) Status {
+	for s.current < len(s.children) {
+		switch s.children[s.current].execute(ctx) {
+		case Success:
+			s.current = 0
+			return Success
+		case Running:
+			return Running
+		}
+		s.current++
+	}
+	s.current = 0
+	return Failure
+}
+
+func (s *MemorySelector) Reset() {
+	abortAll(s.children)
+	s.current = 0
+}
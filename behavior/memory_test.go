@@ -0,0 +1,188 @@
+package behavior
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// tracked는 scripted에 lifecycle 훅 기록을 더한 노드다
+type tracked struct {
+	scripted
+	name   string
+	events *[]string
+}
+
+func track(events *[]string, name string, results ...Status) *tracked {
+	return &tracked{scripted: scripted{results: results}, name: name, events: events}
+}
+
+func (t *tracked) OnEnter() {
+	*t.events = append(*t.events, t.name+" enter")
+}
+
+func (t *tracked) OnExit(status Status) {
+	*t.events = append(*t.events, t.name+" exit "+status.String())
+}
+
+func (t *tracked) Reset() {
+	*t.events = append(*t.events, t.name+" reset")
+}
+
+func TestMemoryComposites(t *testing.T) {
+	tests := []struct {
+		name string
+		node func(a, b Node) Node
+		a, b []Status
+		want []Status
+		// 각 자식이 실행된 횟수
+		wantA, wantB int
+	}{
+		{
+			name:  "memory sequence resumes the running child",
+			node:  func(a, b Node) Node { return NewMemorySequence(a, b) },
+			a:     []Status{Success},
+			b:     []Status{Running, Running, Success},
+			want:  []Status{Running, Running, Success},
+			wantA: 1,
+			wantB: 3,
+		},
+		{
+			name:  "sequence re-checks earlier children",
+			node:  func(a, b Node) Node { return NewSequence(a, b) },
+			a:     []Status{Success},
+			b:     []Status{Running, Running, Success},
+			want:  []Status{Running, Running, Success},
+			wantA: 3,
+			wantB: 3,
+		},
+		{
+			name:  "memory sequence starts over after failing",
+			node:  func(a, b Node) Node { return NewMemorySequence(a, b) },
+			a:     []Status{Success},
+			b:     []Status{Running, Failure, Success},
+			want:  []Status{Running, Failure, Success},
+			wantA: 2,
+			wantB: 3,
+		},
+		{
+			name:  "memory selector resumes the running child",
+			node:  func(a, b Node) Node { return NewMemorySelector(a, b) },
+			a:     []Status{Failure, Success},
+			b:     []Status{Running, Running, Failure},
+			want:  []Status{Running, Running, Failure},
+			wantA: 1,
+			wantB: 3,
+		},
+		{
+			name:  "selector lets an earlier child take over",
+			node:  func(a, b Node) Node { return NewSelector(a, b) },
+			a:     []Status{Failure, Success},
+			b:     []Status{Running},
+			want:  []Status{Running, Success},
+			wantA: 2,
+			wantB: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := script(tt.a...), script(tt.b...)
+			node := tt.node(a, b)
+			for i, want := range tt.want {
+				if got := node.Execute(&Context{}); got != want {
+					t.Fatalf("tick %d: got %v, want %v", i, got, want)
+				}
+			}
+			if a.calls != tt.wantA || b.calls != tt.wantB {
+				t.Fatalf("children ran %d and %d times, want %d and %d", a.calls, b.calls, tt.wantA, tt.wantB)
+			}
+		})
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	tests := []struct {
+		name string
+		// tree는 기록용 자식 a, b로 테스트할 트리를 만들고, ticks번 실행한 뒤 abort가 참이면 중단시킨다
+		tree  func(events *[]string) Node
+		ticks int
+		abort bool
+		want  []string
+	}{
+		{
+			name: "hooks wrap a run",
+			tree: func(events *[]string) Node {
+				return NewSequence(track(events, "a", Running, Success))
+			},
+			ticks: 2,
+			want:  []string{"a enter", "a exit success"},
+		},
+		{
+			name: "selector aborts a pre-empted child",
+			tree: func(events *[]string) Node {
+				return NewSelector(track(events, "a", Failure, Success), track(events, "b", Running))
+			},
+			ticks: 2,
+			want: []string{
+				"a enter", "a exit failure", "b enter",
+				"a enter", "a exit success", "b reset", "b exit running",
+			},
+		},
+		{
+			name: "sequence aborts a child it no longer reaches",
+			tree: func(events *[]string) Node {
+				return NewSequence(track(events, "a", Success, Failure), track(events, "b", Running))
+			},
+			ticks: 2,
+			want: []string{
+				"a enter", "a exit success", "b enter",
+				"a enter", "a exit failure", "b reset", "b exit running",
+			},
+		},
+		{
+			name: "condition aborts its child when it turns false",
+			tree: func(events *[]string) Node {
+				// 첫 틱에만 참이다
+				checks := 0
+				return NewCondition(func(*Context) bool { checks++; return checks == 1 }, track(events, "a", Running))
+			},
+			ticks: 2,
+			want:  []string{"a enter", "a reset", "a exit running"},
+		},
+		{
+			name: "aborting the tree reaches nested running nodes",
+			tree: func(events *[]string) Node {
+				return NewMemorySequence(track(events, "a", Success), NewInverter(track(events, "b", Running)))
+			},
+			ticks: 1,
+			abort: true,
+			want:  []string{"a enter", "a exit success", "b enter", "b reset", "b exit running"},
+		},
+		{
+			name: "aborting a finished tree does nothing",
+			tree: func(events *[]string) Node {
+				return NewSequence(track(events, "a", Success))
+			},
+			ticks: 1,
+			abort: true,
+			want:  []string{"a enter", "a exit success"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []string
+			tree := &Tree{root: newSlot(tt.tree(&events))}
+			for i := 0; i < tt.ticks; i++ {
+				tree.Tick(nil, time.Time{}, 0)
+			}
+			if tt.abort {
+				tree.Abort()
+			}
+			if !slices.Equal(events, tt.want) {
+				t.Fatalf("events = %q\nwant     %q", events, tt.want)
+			}
+		})
+	}
+}
@@ -2,23 +2,59 @@ package behavior
 
 import "time"
 
+// 몬스터 행동 트리에 쓰이는 수치. 속도는 초당 이동 거리다.
+type MonsterParams struct {
+	DetectRange    float32
+	AttackRange    float32
+	AttackDamage   int
+	AttackCooldown time.Duration
+	ChaseSpeed     float32
+	PatrolSpeed    float32
+	RepathDistance float32
+}
+
+// stat returns the template stat called name, for "$name" params in tree definitions
+func (p MonsterParams) stat(name string) (any, bool) {
+	switch name {
+	case "detect_range":
+		return p.DetectRange, true
+	case "attack_range":
+		return p.AttackRange, true
+	case "attack_damage":
+		return p.AttackDamage, true
+	case "attack_cooldown":
+		return p.AttackCooldown, true
+	case "chase_speed":
+		return p.ChaseSpeed, true
+	case "patrol_speed":
+		return p.PatrolSpeed, true
+	case "repath_distance":
+		return p.RepathDistance, true
+	}
+	return nil, false
+}
+
+func init() {
+	RegisterTree("monster1", CreateMonsterBehaviorTree)
+}
+
 // 몬스터의 행동 트리 생성 함수
-func CreateMonsterBehaviorTree(monster *Monster) Node {
+func CreateMonsterBehaviorTree(monster *Monster, params MonsterParams) Node {
 	return NewSelector(
 		// 전투 시퀀스
 		NewSequence(
-			NewDetectPlayer(monster, 10.0), // 감지 범위 10
+			NewDetectPlayer(monster, params.DetectRange), // 감지 범위
 			NewSelector(
 				// 공격 시퀀스
 				NewSequence(
-					NewDetectPlayer(monster, 2.0),            // 공격 범위 2
-					NewAttack(monster, 2.0, 10, time.Second), // 데미지 10, 쿨다운 1초
+					NewDetectPlayer(monster, params.AttackRange), // 공격 범위
+					NewAttack(monster, params.AttackRange, params.AttackDamage, params.AttackCooldown),
 				),
 				// 추적 시퀀스
-				NewChase(monster, 3.0), // 이동 속도 3
+				NewChase(monster, params.ChaseSpeed, params.RepathDistance), // 이동 속도
 			),
 		),
 		// 순찰 행동
-		NewPatrol(monster),
+		NewPatrol(monster, params.PatrolSpeed),
 	)
 }
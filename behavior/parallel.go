@@ -0,0 +1,78 @@
+package behavior
+
+// ParallelPolicy decides how many children of a Parallel must finish with a result
+// before the Parallel itself does
+type ParallelPolicy int
+
+const (
+	// 자식 하나만 그 결과가 나오면 된다
+	RequireOne ParallelPolicy = iota
+	// 모든 자식이 그 결과를 내야 한다
+	RequireAll
+)
+
+// Parallel 노드 - 매 틱 아직 끝나지 않은 자식을 실행한다. 끝난 자식의 결과는 Parallel이 끝날 때까지
+// 유지한다. 실패 조건을 먼저 확인하고, 그다음 성공 조건을 확인한다. 둘 다 아닌데 실행 중인 자식도
+// 없으면 실패한다.
+type Parallel struct {
+	success  ParallelPolicy
+	failure  ParallelPolicy
+	children []*slot
+	// 자식마다 이번 실행에서 낸 결과. 아직 끝나지 않은 자식은 Running이다.
+	results []Status
+}
+
+func NewParallel(success, failure ParallelPolicy, children ...Node) *Parallel {
+	p := &Parallel{success: success, failure: failure, children: newSlots(children)}
+	p.results = make([]Status, len(p.children))
+	p.clearResults()
+	return p
+}
+
+func (p *Parallel) Execute(ctx *Context) Status {
+	var successes, failures int
+	for i, child := range p.children {
+		if p.results[i] == Running {
+			p.results[i] = child.execute(ctx)
+		}
+		switch p.results[i] {
+		case Success:
+			successes++
+		case Failure:
+			failures++
+		}
+	}
+
+	// 결과가 나면 아직 실행 중인 자식은 중단시킨다
+	if p.satisfied(p.failure, failures) {
+		p.Reset()
+		return Failure
+	}
+	if p.satisfied(p.success, successes) {
+		p.Reset()
+		return Success
+	}
+	if successes+failures == len(p.children) {
+		p.Reset()
+		return Failure
+	}
+	return Running
+}
+
+func (p *Parallel) Reset() {
+	abortAll(p.children)
+	p.clearResults()
+}
+
+func (p *Parallel) clearResults() {
+	for i := range p.results {
+		p.results[i] = Running
+	}
+}
+
+func (p *Parallel) satisfied(policy ParallelPolicy, count int) bool {
+	if policy == RequireAll {
+		return count == len(p.children) && count > 0
+	}
+	return count > 0
+}
@@ -0,0 +1,71 @@
+package behavior
+
+import "testServer/vecmath"
+
+// PathFinder finds a walkable path between two game-space points. The returned
+// waypoints end at to or close to it.
+type PathFinder interface {
+	FindPath(from, to vecmath.Vec3) ([]vecmath.Vec3, error)
+}
+
+// 목표가 이만큼 움직이면 경로를 다시 찾는다 (템플릿에서 정하지 않았을 때)
+const DefaultRepathDistance = 2.0
+
+// pathFollower walks a monster along waypoints returned by the World
+type pathFollower struct {
+	path []vecmath.Vec3
+	idx  int
+	// 마지막으로 경로를 따라 움직인 위치. 다른 노드가 몬스터를 옮겼으면 경로를 버린다.
+	last vecmath.Vec3
+}
+
+// findPath replaces the current path with one to to. It reports whether a path was found.
+func (f *pathFollower) findPath(ctx *Context, monster *Monster, to vecmath.Vec3) bool {
+	f.idx = 0
+	f.last = monster.Pos
+
+	path, err := ctx.findPath(monster.Pos, to)
+	if err != nil || len(path) == 0 {
+		f.path = nil
+		return false
+	}
+	f.path = path
+	return true
+}
+
+// hasPath reports whether there are waypoints left to follow from where the monster is
+func (f *pathFollower) hasPath(monster *Monster) bool {
+	return f.idx < len(f.path) && monster.Pos == f.last
+}
+
+// clear drops the current path
+func (f *pathFollower) clear() {
+	f.path = nil
+	f.idx = 0
+}
+
+// step moves monster up to distance along the path and reports whether it reached the end.
+// Distance is measured on the X/Z plane; the height follows the waypoints.
+func (f *pathFollower) step(monster *Monster, distance float32) bool {
+	defer func() {
+		f.last = monster.Pos
+	}()
+
+	remaining := distance
+	for f.idx < len(f.path) {
+		waypoint := f.path[f.idx]
+		dist := monster.Pos.DistanceXZ(waypoint)
+
+		// 이번 틱 안에 닿는 웨이포인트는 지나서 다음 웨이포인트로 계속 간다
+		if dist <= remaining {
+			monster.Pos = waypoint
+			remaining -= dist
+			f.idx++
+			continue
+		}
+
+		monster.Pos = monster.Pos.Lerp(waypoint, remaining/dist)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,334 @@
+package behavior
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NodeSpec describes a node type that tree definition files can use
+type NodeSpec struct {
+	// 자식 수 범위. MaxChildren이 음수면 제한이 없다.
+	MinChildren, MaxChildren int
+	// Build makes the node. Bad params are reported through args.Errorf.
+	Build func(args *NodeArgs) Node
+}
+
+// 이름으로 찾을 수 있는 노드 타입 목록 (트리 정의 파일의 type 값)
+var nodeTypes = map[string]NodeSpec{}
+
+// RegisterNode makes a node type available to tree definitions under name.
+// Call it from init; registering the same name twice panics.
+func RegisterNode(name string, spec NodeSpec) {
+	if _, exists := nodeTypes[name]; exists {
+		panic(fmt.Sprintf("behavior node type %q registered twice", name))
+	}
+	nodeTypes[name] = spec
+}
+
+// Check is a named condition that condition nodes in tree definitions can test
+type Check func(monster *Monster, bb *Blackboard) bool
+
+var checks = map[string]Check{}
+
+// RegisterCheck makes a check available to condition nodes under name.
+// Call it from init; registering the same name twice panics.
+func RegisterCheck(name string, check Check) {
+	if _, exists := checks[name]; exists {
+		panic(fmt.Sprintf("behavior check %q registered twice", name))
+	}
+	checks[name] = check
+}
+
+// NodeArgs is what a NodeSpec builds its node from. Param getters accept a literal or a
+// "$name" reference to a template stat (see MonsterParams.stat) and fall back to the
+// given default when the param is missing.
+type NodeArgs struct {
+	Monster  *Monster
+	Params   MonsterParams
+	Children []Node
+
+	raw  map[string]json.RawMessage
+	used map[string]bool
+	// "$stat"로 채워진 params. structural이면 템플릿 값을 모르므로 그 값은 검사하지 않는다.
+	refs       map[string]bool
+	structural bool
+	errs       []error
+}
+
+// Errorf reports a problem with the node being built
+func (a *NodeArgs) Errorf(format string, args ...any) {
+	a.errs = append(a.errs, fmt.Errorf(format, args...))
+}
+
+// ParamErrorf reports a bad value of param name. When a tree file is validated without a
+// template, "$stat" params only hold placeholder values, so their values are not reported.
+func (a *NodeArgs) ParamErrorf(name string, format string, args ...any) {
+	if a.structural && a.refs[name] {
+		return
+	}
+	a.Errorf(format, args...)
+}
+
+// Float returns the number param name
+func (a *NodeArgs) Float(name string, fallback float32) float32 {
+	value, ok := a.param(name)
+	if !ok {
+		return fallback
+	}
+	switch value := value.(type) {
+	case float64:
+		return float32(value)
+	case float32:
+		return value
+	case int:
+		return float32(value)
+	}
+	a.Errorf("param %q must be a number", name)
+	return fallback
+}
+
+// Int returns the whole number param name
+func (a *NodeArgs) Int(name string, fallback int) int {
+	value, ok := a.param(name)
+	if !ok {
+		return fallback
+	}
+	switch value := value.(type) {
+	case float64:
+		if value == float64(int(value)) {
+			return int(value)
+		}
+	case int:
+		return value
+	}
+	a.Errorf("param %q must be a whole number", name)
+	return fallback
+}
+
+// Duration returns the duration param name, written like "1.5s"
+func (a *NodeArgs) Duration(name string, fallback time.Duration) time.Duration {
+	value, ok := a.param(name)
+	if !ok {
+		return fallback
+	}
+	switch value := value.(type) {
+	case time.Duration:
+		return value
+	case string:
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	a.Errorf("param %q must be a duration like \"1s\"", name)
+	return fallback
+}
+
+// String returns the string param name
+func (a *NodeArgs) String(name string, fallback string) string {
+	value, ok := a.param(name)
+	if !ok {
+		return fallback
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	a.Errorf("param %q must be a string", name)
+	return fallback
+}
+
+// param decodes param name, resolving "$stat" references. It reports false when the
+// param is missing or invalid; invalid params are reported.
+func (a *NodeArgs) param(name string) (any, bool) {
+	raw, exists := a.raw[name]
+	if !exists {
+		return nil, false
+	}
+	a.used[name] = true
+
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		a.Errorf("param %q: %v", name, err)
+		return nil, false
+	}
+	if ref, ok := value.(string); ok && strings.HasPrefix(ref, "$") {
+		stat, exists := a.Params.stat(ref[1:])
+		if !exists {
+			a.Errorf("param %q: unknown stat %q", name, ref)
+			return nil, false
+		}
+		a.refs[name] = true
+		return stat, true
+	}
+	return value, true
+}
+
+// unused returns the params no getter asked for, sorted
+func (a *NodeArgs) unused() []string {
+	var names []string
+	for name := range a.raw {
+		if !a.used[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// buildNode builds def and its children, reporting every problem found with its path in the tree.
+// params is nil when only the structure of def is checked.
+func buildNode(def *NodeDefinition, path string, monster *Monster, params *MonsterParams) (Node, []error) {
+	if def == nil {
+		return nil, []error{fmt.Errorf("%s: node is empty", path)}
+	}
+	spec, exists := nodeTypes[def.Type]
+	if !exists {
+		return nil, []error{fmt.Errorf("%s: unknown node type %q", path, def.Type)}
+	}
+	path = fmt.Sprintf("%s (%s)", path, def.Type)
+
+	var errs []error
+	children := make([]Node, 0, len(def.Children))
+	for i, childDef := range def.Children {
+		child, childErrs := buildNode(childDef, fmt.Sprintf("%s.children[%d]", path, i), monster, params)
+		errs = append(errs, childErrs...)
+		children = append(children, child)
+	}
+
+	count := len(def.Children)
+	if count < spec.MinChildren || (spec.MaxChildren >= 0 && count > spec.MaxChildren) {
+		errs = append(errs, fmt.Errorf("%s: has %d children, want %s", path, count, childRange(spec)))
+		return nil, errs
+	}
+
+	args := &NodeArgs{
+		Monster:    monster,
+		Children:   children,
+		raw:        def.Params,
+		used:       make(map[string]bool),
+		refs:       make(map[string]bool),
+		structural: params == nil,
+	}
+	if params != nil {
+		args.Params = *params
+	}
+	// 자식에 문제가 있어도 이 노드의 params까지 확인하도록 만들어 본다. 생성자는 자식을 실행하지 않는다.
+	node := spec.Build(args)
+	for _, name := range args.unused() {
+		args.Errorf("unknown param %q", name)
+	}
+	for _, err := range args.errs {
+		errs = append(errs, fmt.Errorf("%s: %w", path, err))
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return node, nil
+}
+
+func childRange(spec NodeSpec) string {
+	switch {
+	case spec.MaxChildren < 0:
+		return fmt.Sprintf("at least %d", spec.MinChildren)
+	case spec.MinChildren == spec.MaxChildren:
+		return fmt.Sprint(spec.MinChildren)
+	default:
+		return fmt.Sprintf("%d to %d", spec.MinChildren, spec.MaxChildren)
+	}
+}
+
+func parallelPolicy(args *NodeArgs, name string) ParallelPolicy {
+	switch policy := args.String(name, "one"); policy {
+	case "one":
+		return RequireOne
+	case "all":
+		return RequireAll
+	default:
+		args.Errorf("param %q must be \"one\" or \"all\", got %q", name, policy)
+		return RequireOne
+	}
+}
+
+func init() {
+	composite := func(build func(children ...Node) Node) NodeSpec {
+		return NodeSpec{MinChildren: 1, MaxChildren: -1, Build: func(args *NodeArgs) Node {
+			return build(args.Children...)
+		}}
+	}
+	decorator := func(build func(args *NodeArgs, child Node) Node) NodeSpec {
+		return NodeSpec{MinChildren: 1, MaxChildren: 1, Build: func(args *NodeArgs) Node {
+			return build(args, args.Children[0])
+		}}
+	}
+	leaf := func(build func(args *NodeArgs) Node) NodeSpec {
+		return NodeSpec{Build: build}
+	}
+	positive := func(args *NodeArgs, name string, d time.Duration) time.Duration {
+		if d <= 0 {
+			args.ParamErrorf(name, "param %q must be positive", name)
+		}
+		return d
+	}
+
+	RegisterNode("sequence", composite(func(c ...Node) Node { return NewSequence(c...) }))
+	RegisterNode("selector", composite(func(c ...Node) Node { return NewSelector(c...) }))
+	RegisterNode("memory_sequence", composite(func(c ...Node) Node { return NewMemorySequence(c...) }))
+	RegisterNode("memory_selector", composite(func(c ...Node) Node { return NewMemorySelector(c...) }))
+	RegisterNode("parallel", NodeSpec{MinChildren: 1, MaxChildren: -1, Build: func(args *NodeArgs) Node {
+		return NewParallel(parallelPolicy(args, "success"), parallelPolicy(args, "failure"), args.Children...)
+	}})
+
+	RegisterNode("inverter", decorator(func(args *NodeArgs, child Node) Node { return NewInverter(child) }))
+	RegisterNode("succeeder", decorator(func(args *NodeArgs, child Node) Node { return NewSucceeder(child) }))
+	RegisterNode("repeater", decorator(func(args *NodeArgs, child Node) Node {
+		return NewRepeater(child, args.Int("count", 0))
+	}))
+	RegisterNode("retry_until_success", decorator(func(args *NodeArgs, child Node) Node {
+		return NewRetryUntilSuccess(child, args.Int("attempts", 0))
+	}))
+	RegisterNode("timeout", decorator(func(args *NodeArgs, child Node) Node {
+		return NewTimeout(child, positive(args, "duration", args.Duration("duration", 0)))
+	}))
+	RegisterNode("cooldown", decorator(func(args *NodeArgs, child Node) Node {
+		return NewCooldown(child, positive(args, "duration", args.Duration("duration", 0)))
+	}))
+	RegisterNode("condition", decorator(func(args *NodeArgs, child Node) Node {
+		name := args.String("check", "")
+		check, exists := checks[name]
+		if !exists {
+			args.Errorf("unknown check %q", name)
+			return nil
+		}
+		monster := args.Monster
+		return NewCondition(func(ctx *Context) bool { return check(monster, ctx.Blackboard) }, child)
+	}))
+
+	RegisterNode("detect_player", leaf(func(args *NodeArgs) Node {
+		return NewDetectPlayer(args.Monster, args.Float("range", args.Params.DetectRange))
+	}))
+	RegisterNode("attack", leaf(func(args *NodeArgs) Node {
+		return NewAttack(args.Monster,
+			args.Float("range", args.Params.AttackRange),
+			args.Int("damage", args.Params.AttackDamage),
+			args.Duration("cooldown", args.Params.AttackCooldown))
+	}))
+	RegisterNode("chase", leaf(func(args *NodeArgs) Node {
+		return NewChase(args.Monster,
+			args.Float("speed", args.Params.ChaseSpeed),
+			args.Float("repath_distance", args.Params.RepathDistance))
+	}))
+	RegisterNode("patrol", leaf(func(args *NodeArgs) Node {
+		return NewPatrol(args.Monster, args.Float("speed", args.Params.PatrolSpeed))
+	}))
+
+	RegisterCheck("has_target", func(monster *Monster, bb *Blackboard) bool {
+		target, ok := Get(bb, KeyTarget)
+		return ok && target != nil
+	})
+	RegisterCheck("has_patrol_path", func(monster *Monster, bb *Blackboard) bool {
+		path, _ := Get(bb, KeyPatrolPath)
+		return len(path) > 0
+	})
+}
@@ -0,0 +1,80 @@
+package behavior
+
+import (
+	"reflect"
+	"time"
+)
+
+// TraceEntry is one node executed during a traced tick. Depth is how far below the root it is.
+type TraceEntry struct {
+	Depth  int    `json:"depth"`
+	Node   string `json:"node"`
+	Status Status `json:"status"`
+}
+
+// TickTrace is every node a tree executed in one tick, in execution order
+type TickTrace struct {
+	Time  time.Time    `json:"time"`
+	Nodes []TraceEntry `json:"nodes"`
+}
+
+// Tracer records which nodes a tree executed and what they returned over its last ticks.
+// Set it as Tree.Tracer to start recording. It is not safe for concurrent use; the owner of
+// the tree must guard it the same way it guards Tick.
+type Tracer struct {
+	limit int
+	ticks []TickTrace
+	depth int
+}
+
+// NewTracer creates a tracer that keeps the last limit ticks
+func NewTracer(limit int) *Tracer {
+	return &Tracer{limit: max(limit, 1)}
+}
+
+// Ticks returns a copy of the recorded ticks, oldest first
+func (tr *Tracer) Ticks() []TickTrace {
+	ticks := make([]TickTrace, len(tr.ticks))
+	for i, tick := range tr.ticks {
+		ticks[i] = TickTrace{Time: tick.Time, Nodes: append([]TraceEntry(nil), tick.Nodes...)}
+	}
+	return ticks
+}
+
+// begin starts recording the tick at now, dropping the oldest tick past the limit
+func (tr *Tracer) begin(now time.Time) {
+	if len(tr.ticks) == tr.limit {
+		tr.ticks = append(tr.ticks[:0], tr.ticks[1:]...)
+	}
+	tr.ticks = append(tr.ticks, TickTrace{Time: now})
+	tr.depth = 0
+}
+
+// enter records node as started and returns its index for exit. A nil tracer records nothing.
+func (tr *Tracer) enter(node Node) int {
+	if tr == nil {
+		return -1
+	}
+	tick := &tr.ticks[len(tr.ticks)-1]
+	tick.Nodes = append(tick.Nodes, TraceEntry{Depth: tr.depth, Node: nodeName(node)})
+	tr.depth++
+	return len(tick.Nodes) - 1
+}
+
+// exit records the status of the node enter returned index for
+func (tr *Tracer) exit(index int, status Status) {
+	if tr == nil {
+		return
+	}
+	tr.depth--
+	tr.ticks[len(tr.ticks)-1].Nodes[index].Status = status
+}
+
+// nodeName is the type name of node without package or pointer, e.g. "Sequence"
+func nodeName(node Node) string {
+	t := reflect.TypeOf(node)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
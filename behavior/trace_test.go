@@ -0,0 +1,51 @@
+package behavior
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"testServer/vecmath"
+)
+
+func TestTracerRecordsEachTick(t *testing.T) {
+	params := MonsterParams{DetectRange: 10, AttackRange: 2, AttackDamage: 1, AttackCooldown: time.Second, ChaseSpeed: 1}
+	tree, err := NewTree("monster1", &Monster{}, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree.Tracer = NewTracer(2)
+
+	world := fakeWorld{target: fixedTarget(vecmath.New(0, 0, 1))}
+	start := time.Unix(0, 0)
+	for i := range 3 {
+		tree.Tick(world, start.Add(time.Duration(i)*100*time.Millisecond), 100*time.Millisecond)
+	}
+
+	// 가장 오래된 틱은 버리고, 첫 공격 뒤 쿨다운을 기다리는 틱 두 개만 남는다
+	ticks := tree.Tracer.Ticks()
+	if len(ticks) != 2 {
+		t.Fatalf("kept %d ticks, want the last 2", len(ticks))
+	}
+	if !ticks[0].Time.Equal(start.Add(100 * time.Millisecond)) {
+		t.Fatalf("oldest kept tick is at %v, want the second tick", ticks[0].Time)
+	}
+	want := []TraceEntry{
+		{0, "Selector", Running},
+		{1, "Sequence", Running},
+		{2, "DetectPlayer", Success},
+		{2, "Selector", Running},
+		{3, "Sequence", Running},
+		{4, "DetectPlayer", Success},
+		{4, "Attack", Running},
+	}
+	if got := ticks[1].Nodes; !slices.Equal(got, want) {
+		t.Fatalf("traced %v, want %v", got, want)
+	}
+
+	// 기록을 돌려준 뒤의 틱이 이미 돌려준 기록을 바꾸면 안 된다
+	tree.Tick(world, start.Add(time.Second), 100*time.Millisecond)
+	if got := ticks[1].Nodes; !slices.Equal(got, want) {
+		t.Fatalf("returned trace changed to %v", got)
+	}
+}
@@ -0,0 +1,109 @@
+package behavior
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 몬스터 하나의 행동 트리를 만드는 함수
+type TreeFactory func(monster *Monster, params MonsterParams) Node
+
+// 이름으로 찾을 수 있는 행동 트리 목록 (몬스터 템플릿의 tree 값)
+var trees = map[string]TreeFactory{}
+
+// Tree is one instance of a registered tree together with its blackboard
+type Tree struct {
+	Name       string
+	Blackboard *Blackboard
+	// nil이 아니면 매 틱 실행된 노드를 기록한다
+	Tracer  *Tracer
+	root    *slot
+	monster *Monster
+	params  MonsterParams
+}
+
+// Root returns the root node of the tree
+func (t *Tree) Root() Node {
+	return t.root.node
+}
+
+// Tick runs the tree once for a tick of dt ending at the simulated time now.
+// Every node gets a Context with the tree's blackboard and world.
+func (t *Tree) Tick(world World, now time.Time, dt time.Duration) Status {
+	ctx := &Context{DT: dt, Now: now, World: world, Blackboard: t.Blackboard}
+	if t.Tracer != nil {
+		t.Tracer.begin(now)
+		ctx.tracer = t.Tracer
+	}
+	return t.root.execute(ctx)
+}
+
+// Abort interrupts whatever the tree is running, giving every running node a chance to
+// clean up. The next Execute starts from the top.
+func (t *Tree) Abort() {
+	t.root.abort()
+}
+
+// RegisterTree makes a tree available to monster templates under name.
+// Call it from init; registering the same name twice panics.
+func RegisterTree(name string, factory TreeFactory) {
+	if _, exists := trees[name]; exists {
+		panic(fmt.Sprintf("behavior tree %q registered twice", name))
+	}
+	trees[name] = factory
+}
+
+// Reload rebuilds the tree from the current definition of its name, keeping the blackboard.
+// The old tree is aborted first. On error the old tree stays in place.
+func (t *Tree) Reload() error {
+	root, err := buildTree(t.Name, t.monster, t.params)
+	if err != nil {
+		return err
+	}
+	t.root.abort()
+	t.root = newSlot(root)
+	return nil
+}
+
+// HasTree reports whether a tree called name is defined in the tree file in use or registered from code
+func HasTree(name string) bool {
+	if _, exists := definition(name); exists {
+		return true
+	}
+	_, exists := trees[name]
+	return exists
+}
+
+// NewTree builds the tree called name for monster with an empty blackboard
+func NewTree(name string, monster *Monster, params MonsterParams) (*Tree, error) {
+	root, err := buildTree(name, monster, params)
+	if err != nil {
+		return nil, err
+	}
+	return &Tree{Name: name, Blackboard: NewBlackboard(), root: newSlot(root), monster: monster, params: params}, nil
+}
+
+// CheckTree reports whether the tree called name can be built with params, such as those
+// of a monster template. It catches "$stat" params the template's stats make invalid.
+func CheckTree(name string, params MonsterParams) error {
+	_, err := buildTree(name, &Monster{}, params)
+	return err
+}
+
+// buildTree builds the root of the tree called name, preferring the tree file in use over code
+func buildTree(name string, monster *Monster, params MonsterParams) (Node, error) {
+	if def, exists := definition(name); exists {
+		root, errs := buildNode(def, "root", monster, &params)
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("behavior tree %q: %w", name, errors.Join(errs...))
+		}
+		return root, nil
+	}
+
+	factory, exists := trees[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown behavior tree %q", name)
+	}
+	return factory(monster, params), nil
+}
@@ -0,0 +1,42 @@
+// hashpassword prints the bcrypt hash of a password for the users file.
+//
+//	go run ./cmd/hashpassword secret
+//	echo secret | go run ./cmd/hashpassword
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	mg "testServer/Manager"
+)
+
+func main() {
+	var password string
+	switch len(os.Args) {
+	case 1:
+		// 인자가 없으면 셸 기록에 남지 않도록 표준 입력에서 한 줄을 읽습니다
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			log.Fatalf("Failed to read password: %v", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	case 2:
+		password = os.Args[1]
+	default:
+		fmt.Fprintln(os.Stderr, "usage: hashpassword [password]")
+		os.Exit(2)
+	}
+
+	if password == "" {
+		log.Fatal("Password must not be empty")
+	}
+	hash, err := mg.HashPassword(password)
+	if err != nil {
+		log.Fatalf("Failed to hash password: %v", err)
+	}
+	fmt.Println(hash)
+}
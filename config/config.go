@@ -0,0 +1,303 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// 설정 우선순위: 기본값 < 설정 파일 < 환경 변수 < 커맨드라인 플래그
+const envPrefix = "TESTSERVER_"
+
+// Config holds every tunable the server reads at startup
+type Config struct {
+	Server   ServerConfig   `json:"server"`
+	World    WorldConfig    `json:"world"`
+	NavMesh  NavMeshConfig  `json:"navmesh"`
+	Monster  MonsterConfig  `json:"monster"`
+	PathTest PathTestConfig `json:"path_test"`
+	Auth     AuthConfig     `json:"auth"`
+	Movement MovementConfig `json:"movement"`
+	Combat   CombatConfig   `json:"combat"`
+}
+
+type ServerConfig struct {
+	ListenAddr      string   `json:"listen_addr"`
+	MaxPacketSize   int      `json:"max_packet_size"`
+	SendQueueSize   int      `json:"send_queue_size"`
+	Backpressure    string   `json:"backpressure"`
+	ShutdownTimeout Duration `json:"shutdown_timeout"`
+	WriteTimeout    Duration `json:"write_timeout"`
+	PingInterval    Duration `json:"ping_interval"`
+	IdleTimeout     Duration `json:"idle_timeout"`
+	AdminAddr       string   `json:"admin_addr"`
+	RateLimit       float64  `json:"rate_limit"`
+	RateBurst       int      `json:"rate_burst"`
+}
+
+// WorldConfig tunes the simulation loop. view_radius is how far players see
+// other players and monsters.
+type WorldConfig struct {
+	TickRate   int     `json:"tick_rate"`
+	ViewRadius float32 `json:"view_radius"`
+}
+
+type NavMeshConfig struct {
+	Path string `json:"path"`
+}
+
+// MonsterConfig points at the data file with the monster templates and spawn regions, and
+// the optional behavior tree definitions. Without a trees file only the built-in trees exist.
+type MonsterConfig struct {
+	DataFile  string `json:"data_file"`
+	TreesFile string `json:"trees_file"`
+}
+
+// CombatConfig tunes player attacks on monsters
+type CombatConfig struct {
+	AttackRange    float32  `json:"attack_range"`
+	AttackDamage   int      `json:"attack_damage"`
+	AttackCooldown Duration `json:"attack_cooldown"`
+}
+
+// AuthConfig configures login. An empty token_secret generates a random one at startup.
+// duplicate_login is kick_old, reject_new or resume.
+type AuthConfig struct {
+	UsersFile      string   `json:"users_file"`
+	TokenSecret    string   `json:"token_secret"`
+	TokenTTL       Duration `json:"token_ttl"`
+	DuplicateLogin string   `json:"duplicate_login"`
+}
+
+// MovementConfig tunes server-side validation of player moves. A player may cover
+// max_speed*speed_tolerance units per second, saving up at most burst worth of
+// movement for bunched packets, and stay within height_tolerance of the navmesh height.
+// on_violation is correct (reject the move) or clamp.
+type MovementConfig struct {
+	MaxSpeed        float32  `json:"max_speed"`
+	SpeedTolerance  float32  `json:"speed_tolerance"`
+	Burst           Duration `json:"burst"`
+	HeightTolerance float32  `json:"height_tolerance"`
+	OnViolation     string   `json:"on_violation"`
+}
+
+// PathTestConfig controls the debug path sent to players on login. From and To are
+// game-space x, y, z with y as the height.
+type PathTestConfig struct {
+	Enabled bool       `json:"enabled"`
+	From    [3]float64 `json:"from"`
+	To      [3]float64 `json:"to"`
+}
+
+// Duration is a time.Duration written as a string like "5s" in config files
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"5s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			ListenAddr:      ":9090",
+			MaxPacketSize:   64 * 1024,
+			SendQueueSize:   256,
+			Backpressure:    "kick",
+			ShutdownTimeout: Duration(5 * time.Second),
+			WriteTimeout:    Duration(10 * time.Second),
+			PingInterval:    Duration(5 * time.Second),
+			IdleTimeout:     Duration(30 * time.Second),
+			AdminAddr:       "",
+			RateLimit:       60,
+			RateBurst:       120,
+		},
+		World: WorldConfig{
+			TickRate:   20,
+			ViewRadius: 50,
+		},
+		// 네비메시는 레벨에서 내보낸 파일이라 저장소에 없다. 서버 옆에 NavMeshData.json을 두거나 -navmesh로 지정한다.
+		NavMesh: NavMeshConfig{
+			Path: "NavMeshData.json",
+		},
+		// 몬스터와 계정 데이터는 저장소에 있는 예제 파일을 기본으로 쓴다
+		Monster: MonsterConfig{
+			DataFile: "monsters.example.json",
+		},
+		PathTest: PathTestConfig{
+			Enabled: true,
+			From:    [3]float64{-230, 0, -291},
+			To:      [3]float64{235, 0, 180},
+		},
+		Auth: AuthConfig{
+			UsersFile:      "users.example.json",
+			TokenTTL:       Duration(24 * time.Hour),
+			DuplicateLogin: "kick_old",
+		},
+		Movement: MovementConfig{
+			MaxSpeed:        8,
+			SpeedTolerance:  1.25,
+			Burst:           Duration(time.Second),
+			HeightTolerance: 2,
+			OnViolation:     "clamp",
+		},
+		Combat: CombatConfig{
+			AttackRange:    3.0,
+			AttackDamage:   25,
+			AttackCooldown: Duration(500 * time.Millisecond),
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the optional config file,
+// TESTSERVER_* environment variables and command-line args, then validates it.
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("testServer", flag.ContinueOnError)
+	path := fs.String("config", os.Getenv(envPrefix+"CONFIG"), "path to a JSON config file")
+	listen := fs.String("listen", "", "address to listen on")
+	navMesh := fs.String("navmesh", "", "path to the navmesh data file")
+	tickRate := fs.Int("tick-rate", 0, "world ticks per second")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 0, "how long to wait for clients to drain on shutdown")
+	adminAddr := fs.String("admin", "", "address for the admin HTTP endpoint (empty disables it)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *path != "" {
+		if err := cfg.loadFile(*path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	// 명시적으로 넘긴 플래그만 덮어쓴다
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "listen":
+			cfg.Server.ListenAddr = *listen
+		case "navmesh":
+			cfg.NavMesh.Path = *navMesh
+		case "tick-rate":
+			cfg.World.TickRate = *tickRate
+		case "shutdown-timeout":
+			cfg.Server.ShutdownTimeout = Duration(*shutdownTimeout)
+		case "admin":
+			cfg.Server.AdminAddr = *adminAddr
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open config: %w", err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("decode config %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) applyEnv() error {
+	if v, ok := os.LookupEnv(envPrefix + "LISTEN_ADDR"); ok {
+		c.Server.ListenAddr = v
+	}
+	if v, ok := os.LookupEnv(envPrefix + "NAVMESH_PATH"); ok {
+		c.NavMesh.Path = v
+	}
+	if v, ok := os.LookupEnv(envPrefix + "TOKEN_SECRET"); ok {
+		c.Auth.TokenSecret = v
+	}
+	if v, ok := os.LookupEnv(envPrefix + "ADMIN_ADDR"); ok {
+		c.Server.AdminAddr = v
+	}
+	if v, ok := os.LookupEnv(envPrefix + "TICK_RATE"); ok {
+		tickRate, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%sTICK_RATE: %w", envPrefix, err)
+		}
+		c.World.TickRate = tickRate
+	}
+	if v, ok := os.LookupEnv(envPrefix + "SHUTDOWN_TIMEOUT"); ok {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("%sSHUTDOWN_TIMEOUT: %w", envPrefix, err)
+		}
+		c.Server.ShutdownTimeout = Duration(timeout)
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.ListenAddr != "", "server.listen_addr must be set")
+	check(c.Server.MaxPacketSize > 0, "server.max_packet_size must be positive, got %d", c.Server.MaxPacketSize)
+	check(c.Server.SendQueueSize > 0, "server.send_queue_size must be positive, got %d", c.Server.SendQueueSize)
+	check(c.Server.Backpressure == "drop" || c.Server.Backpressure == "kick" || c.Server.Backpressure == "block",
+		"server.backpressure must be drop, kick or block, got %q", c.Server.Backpressure)
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+	check(c.Server.WriteTimeout >= 0, "server.write_timeout must not be negative")
+	check(c.Server.PingInterval >= 0, "server.ping_interval must not be negative")
+	check(c.Server.IdleTimeout >= 0, "server.idle_timeout must not be negative")
+	check(c.Server.IdleTimeout == 0 || c.Server.PingInterval == 0 || c.Server.IdleTimeout > c.Server.PingInterval,
+		"server.idle_timeout must be longer than server.ping_interval")
+	check(c.Server.RateLimit > 0, "server.rate_limit must be positive")
+	check(c.Server.RateBurst > 0, "server.rate_burst must be positive")
+	check(c.World.TickRate > 0 && c.World.TickRate <= 1000, "world.tick_rate must be between 1 and 1000, got %d", c.World.TickRate)
+	check(c.World.ViewRadius > 0, "world.view_radius must be positive")
+	check(c.NavMesh.Path != "", "navmesh.path must be set")
+	check(c.Monster.DataFile != "", "monster.data_file must be set")
+	check(c.Combat.AttackRange > 0, "combat.attack_range must be positive")
+	check(c.Combat.AttackDamage > 0, "combat.attack_damage must be positive")
+	check(c.Combat.AttackCooldown >= 0, "combat.attack_cooldown must not be negative")
+	check(c.Auth.UsersFile != "", "auth.users_file must be set")
+	check(c.Auth.TokenTTL > 0, "auth.token_ttl must be positive")
+	check(c.Auth.DuplicateLogin == "kick_old" || c.Auth.DuplicateLogin == "reject_new" || c.Auth.DuplicateLogin == "resume",
+		"auth.duplicate_login must be kick_old, reject_new or resume, got %q", c.Auth.DuplicateLogin)
+	check(c.Movement.MaxSpeed > 0, "movement.max_speed must be positive")
+	check(c.Movement.SpeedTolerance >= 1, "movement.speed_tolerance must be at least 1")
+	check(c.Movement.Burst > 0, "movement.burst must be positive")
+	check(c.Movement.HeightTolerance >= 0, "movement.height_tolerance must not be negative")
+	check(c.Movement.OnViolation == "correct" || c.Movement.OnViolation == "clamp",
+		"movement.on_violation must be correct or clamp, got %q", c.Movement.OnViolation)
+
+	return errors.Join(errs...)
+}
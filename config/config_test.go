@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// 기본값 < 설정 파일 < 환경 변수 < 커맨드라인 플래그
+func TestLoadPrecedence(t *testing.T) {
+	path := writeConfig(t, `{"server": {"listen_addr": "file:1"}, "world": {"tick_rate": 10}}`)
+
+	tests := []struct {
+		name       string
+		env        map[string]string
+		args       []string
+		wantListen string
+		wantTick   int
+	}{
+		{"defaults", nil, nil, ":9090", 20},
+		{"file over defaults", nil, []string{"-config", path}, "file:1", 10},
+		{"config path from env", map[string]string{"TESTSERVER_CONFIG": path}, nil, "file:1", 10},
+		{
+			"env over file",
+			map[string]string{"TESTSERVER_LISTEN_ADDR": "env:2", "TESTSERVER_TICK_RATE": "30"},
+			[]string{"-config", path},
+			"env:2", 30,
+		},
+		{
+			"flag over env",
+			map[string]string{"TESTSERVER_LISTEN_ADDR": "env:2", "TESTSERVER_TICK_RATE": "30"},
+			[]string{"-config", path, "-listen", "flag:3", "-tick-rate", "40"},
+			"flag:3", 40,
+		},
+		{
+			"unset flag keeps env",
+			map[string]string{"TESTSERVER_LISTEN_ADDR": "env:2"},
+			[]string{"-config", path, "-tick-rate", "40"},
+			"env:2", 40,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			cfg, err := Load(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Server.ListenAddr != tt.wantListen || cfg.World.TickRate != tt.wantTick {
+				t.Fatalf("listen = %q, tick_rate = %d, want %q, %d",
+					cfg.Server.ListenAddr, cfg.World.TickRate, tt.wantListen, tt.wantTick)
+			}
+		})
+	}
+}
+
+func TestLoadKeepsDefaultsMissingFromFile(t *testing.T) {
+	path := writeConfig(t, `{"server": {"shutdown_timeout": "2s"}}`)
+	cfg, err := Load([]string{"-config", path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.ShutdownTimeout != Duration(2*time.Second) {
+		t.Fatalf("shutdown_timeout = %v, want 2s", time.Duration(cfg.Server.ShutdownTimeout))
+	}
+	if want := Default().Server; cfg.Server.ListenAddr != want.ListenAddr || cfg.Server.MaxPacketSize != want.MaxPacketSize {
+		t.Fatalf("server = %+v, want the other settings left at their defaults", cfg.Server)
+	}
+}
+
+func TestLoadRejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		wantErr string
+	}{
+		{"unknown field", `{"server": {"listen_adr": ":1"}}`, nil, "unknown field"},
+		{"unknown section", `{"sever": {}}`, nil, "unknown field"},
+		{"duration as number", `{"server": {"shutdown_timeout": 5}}`, nil, "duration must be a string"},
+		{"bad env number", `{}`, map[string]string{"TESTSERVER_TICK_RATE": "fast"}, "TESTSERVER_TICK_RATE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			_, err := Load([]string{"-config", writeConfig(t, tt.file)})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Fatalf("default config is invalid: %v", err)
+	}
+
+	cfg := Default()
+	cfg.Server.ListenAddr = ""
+	cfg.Server.Backpressure = "panic"
+	cfg.World.TickRate = 0
+	cfg.Movement.OnViolation = "ignore"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("invalid config passed validation")
+	}
+	for _, want := range []string{
+		"server.listen_addr",
+		"server.backpressure",
+		"world.tick_rate",
+		"movement.on_violation",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s:\n%v", want, err)
+		}
+	}
+}
@@ -5,3 +5,5 @@ go 1.23.1
 require google.golang.org/protobuf v1.34.2
 
 require github.com/hqpko/navmesh v0.0.0-20200715091004-3359b19d8ba0
+
+require golang.org/x/crypto v0.26.0
@@ -1,87 +1,149 @@
 package main
 
 import (
-	"encoding/binary"
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"testServer/config"
 
 	pb "testServer/Messages"
 
 	mg "testServer/Manager"
-
-	"google.golang.org/protobuf/proto"
 )
 
 func main() {
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if err := mg.Init(cfg); err != nil {
+		log.Fatalf("Failed to initialize: %v", err)
+	}
 
-	listener, err := net.Listen("tcp", ":9090")
+	// SIGINT/SIGTERM을 받으면 더 이상 접속을 받지 않고 종료 절차를 시작합니다
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", cfg.Server.ListenAddr)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
-	defer listener.Close()
-	fmt.Println("Server is listening on :9090")
+	fmt.Println("Server is listening on", cfg.Server.ListenAddr)
+
+	world := mg.GetWorld()
+	world.Start()
+
+	if cfg.Server.AdminAddr != "" {
+		if err := mg.GetAdminManager().Start(cfg.Server.AdminAddr); err != nil {
+			log.Fatalf("Failed to start admin endpoint: %v", err)
+		}
+	}
+
+	go func() {
+		<-ctx.Done()
+		// 신호 처리를 원래대로 돌려 두 번째 SIGINT는 종료를 기다리지 않고 바로 끝냅니다
+		stop()
+		listener.Close()
+	}()
 
+	// SIGHUP을 받으면 행동 트리 정의 파일을 다시 읽습니다
+	if cfg.Monster.TreesFile != "" {
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		go func() {
+			for range reload {
+				if err := mg.GetMonsterManager().ReloadTrees(); err != nil {
+					log.Printf("Failed to reload behavior trees: %v", err)
+					continue
+				}
+				log.Printf("Reloaded behavior trees from %s", cfg.Monster.TreesFile)
+			}
+		}()
+	}
+
+	var connections sync.WaitGroup
 	for {
 		conn, err := listener.Accept()
 		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
 			log.Printf("Failed to accept connection: %v", err)
 			continue
 		}
-		go handleConnection(conn)
+
+		connections.Add(1)
+		go func() {
+			defer connections.Done()
+			handleConnection(ctx, conn)
+		}()
 	}
+
+	shutdown(&connections, time.Duration(cfg.Server.ShutdownTimeout))
 }
 
-func handleConnection(conn net.Conn) {
-	defer conn.Close()
-	for {
-		// 메시지 길이를 먼저 읽습니다 (4바이트)
-		lengthBuf := make([]byte, 4)
-		_, err := conn.Read(lengthBuf)
-		if err != nil {
-			log.Printf("Failed to read message length: %v", err)
-			return
-		}
-		length := binary.LittleEndian.Uint32(lengthBuf)
+// shutdown notifies clients, runs the persistence hooks and drains every connection
+func shutdown(connections *sync.WaitGroup, timeout time.Duration) {
+	log.Printf("Shutting down, draining connections for up to %v", timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-		// 메시지 본문을 읽습니다
-		messageBuf := make([]byte, length)
-		_, err = conn.Read(messageBuf)
-		if err != nil {
-			log.Printf("Failed to read message body: %v", err)
-			return
-		}
+	// 월드 루프를 멈춰 더 이상 상태가 바뀌지 않게 합니다
+	mg.GetWorld().Stop()
 
-		// Protocol Buffers 메시지를 파싱합니다
-		message := &pb.GameMessage{}
-		err = proto.Unmarshal(messageBuf, message)
-		if err != nil {
-			log.Printf("Failed to unmarshal message: %v", err)
-			continue
-		}
+	mg.GetSessionManager().Broadcast(&pb.GameMessage{
+		Message: &pb.GameMessage_ServerShutdown{
+			ServerShutdown: &pb.ServerShutdown{Reason: "server is shutting down"},
+		},
+	})
 
-		// 메시지 처리
-		processMessage(message, &conn)
+	mg.RunShutdownHooks(ctx)
+
+	// 남은 패킷을 보내고 연결을 닫습니다
+	deadline, _ := ctx.Deadline()
+	mg.GetSessionManager().ShutdownAll(time.Until(deadline))
 
+	done := make(chan struct{})
+	go func() {
+		connections.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Printf("All connections closed")
+	case <-ctx.Done():
+		log.Printf("Shutdown deadline reached with connections still open")
 	}
+
+	mg.GetAdminManager().Shutdown(ctx)
 }
 
-func processMessage(message *pb.GameMessage, conn *net.Conn) {
-	switch msg := message.Message.(type) {
-	case *pb.GameMessage_PlayerPosition:
-		mg.GetPlayerManager().MovePlayer(msg)
-	case *pb.GameMessage_Chat:
-		chat := msg.Chat
-		mg.GetChatManager().Broadcast(chat.Sender, chat.Content)
-	case *pb.GameMessage_Login:
-		playerId := msg.Login.PlayerId
-		playerManager := mg.GetPlayerManager()
-		playerManager.AddPlayer(playerId, 0, conn)
-	case *pb.GameMessage_Logout:
-		playerId := msg.Logout.PlayerId
-		playerManager := mg.GetPlayerManager()
-		playerManager.RemovePlayer(playerId)
-	default:
-		panic(fmt.Sprintf("unexpected messages.isGameMessage_Message: %#v", msg))
-	}
+func handleConnection(ctx context.Context, conn net.Conn) {
+	session := mg.GetNetManager().NewSession(conn)
+	session.Start()
+	mg.GetSessionManager().AddSession(session)
+	defer func() {
+		// 연결이 끊어지면 (EOF, 오류, 타임아웃) 로그인된 플레이어를 로그아웃 처리합니다
+		session.Close()
+		mg.GetPlayerManager().HandleDisconnect(session)
+		mg.GetSessionManager().RemoveSession(session)
+	}()
+
+	mg.GetNetManager().ReadLoop(session, func(session *mg.Session, message *pb.GameMessage) {
+		// 종료가 시작된 뒤에 온 메시지는 처리하지 않고 연결이 닫히기를 기다립니다
+		if ctx.Err() != nil {
+			return
+		}
+
+		// 메시지 처리
+		mg.GetDispatcher().Dispatch(session, message)
+	})
 }
@@ -0,0 +1,72 @@
+// Package vecmath holds the vector type shared by the game logic.
+//
+// 좌표계는 Unity와 같다: X/Z가 바닥 평면이고 Y가 높이다. 네비메시 라이브러리는
+// Y/Z가 바뀐 좌표를 쓰므로 변환은 NavMeshManager 안에서만 한다.
+package vecmath
+
+import "math"
+
+// Vec3 is a position or direction in game space
+type Vec3 struct {
+	X, Y, Z float32
+}
+
+// New returns the vector (x, y, z)
+func New(x, y, z float32) Vec3 {
+	return Vec3{X: x, Y: y, Z: z}
+}
+
+func (v Vec3) Add(o Vec3) Vec3 {
+	return Vec3{X: v.X + o.X, Y: v.Y + o.Y, Z: v.Z + o.Z}
+}
+
+func (v Vec3) Sub(o Vec3) Vec3 {
+	return Vec3{X: v.X - o.X, Y: v.Y - o.Y, Z: v.Z - o.Z}
+}
+
+func (v Vec3) Scale(s float32) Vec3 {
+	return Vec3{X: v.X * s, Y: v.Y * s, Z: v.Z * s}
+}
+
+// Length returns the length of v
+func (v Vec3) Length() float32 {
+	return float32(math.Sqrt(float64(v.X*v.X + v.Y*v.Y + v.Z*v.Z)))
+}
+
+// Distance returns the distance between v and o
+func (v Vec3) Distance(o Vec3) float32 {
+	return o.Sub(v).Length()
+}
+
+// XZ projects v onto the ground plane by dropping its height
+func (v Vec3) XZ() Vec3 {
+	return Vec3{X: v.X, Z: v.Z}
+}
+
+// DistanceXZ returns the distance between v and o on the ground plane, ignoring height
+func (v Vec3) DistanceXZ(o Vec3) float32 {
+	return v.XZ().Distance(o.XZ())
+}
+
+// Normalize returns v scaled to length 1, or the zero vector if v has no length
+func (v Vec3) Normalize() Vec3 {
+	length := v.Length()
+	if length == 0 {
+		return Vec3{}
+	}
+	return v.Scale(1 / length)
+}
+
+// Lerp returns the point t of the way from v to o
+func (v Vec3) Lerp(o Vec3, t float32) Vec3 {
+	return v.Add(o.Sub(v).Scale(t))
+}
+
+// MoveTowards moves v up to maxDistance toward o without overshooting it
+func (v Vec3) MoveTowards(o Vec3, maxDistance float32) Vec3 {
+	distance := v.Distance(o)
+	if distance <= maxDistance || distance == 0 {
+		return o
+	}
+	return v.Lerp(o, maxDistance/distance)
+}
@@ -0,0 +1,80 @@
+package vecmath
+
+import (
+	"math"
+	"testing"
+)
+
+func approx(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 1e-5
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   Vec3
+		want   float32
+		wantXZ float32
+	}{
+		{"same point", New(1, 2, 3), New(1, 2, 3), 0, 0},
+		{"along x", New(0, 0, 0), New(3, 0, 0), 3, 3},
+		{"along z", New(0, 0, 0), New(0, 0, 4), 4, 4},
+		{"ground diagonal", New(0, 0, 0), New(3, 0, 4), 5, 5},
+		// 높이 차이는 바닥 거리에 들어가지 않는다
+		{"height only", New(0, 0, 0), New(0, 10, 0), 10, 0},
+		{"ground and height", New(1, 5, 1), New(4, -7, 5), 13, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Distance(tt.b); !approx(got, tt.want) {
+				t.Errorf("Distance = %v, want %v", got, tt.want)
+			}
+			if got := tt.a.DistanceXZ(tt.b); !approx(got, tt.wantXZ) {
+				t.Errorf("DistanceXZ = %v, want %v", got, tt.wantXZ)
+			}
+		})
+	}
+}
+
+func TestXZ(t *testing.T) {
+	if got := New(1, 2, 3).XZ(); got != New(1, 0, 3) {
+		t.Fatalf("XZ = %v, want the height dropped and X/Z kept", got)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if got := New(0, 0, 0).Normalize(); got != (Vec3{}) {
+		t.Fatalf("zero vector normalized to %v", got)
+	}
+	got := New(3, 0, 4).Normalize()
+	if !approx(got.X, 0.6) || got.Y != 0 || !approx(got.Z, 0.8) || !approx(got.Length(), 1) {
+		t.Fatalf("Normalize = %v", got)
+	}
+}
+
+func TestLerp(t *testing.T) {
+	a, b := New(0, 0, 0), New(10, 20, 30)
+	for _, tt := range []struct {
+		t    float32
+		want Vec3
+	}{
+		{0, a},
+		{1, b},
+		{0.5, New(5, 10, 15)},
+	} {
+		if got := a.Lerp(b, tt.t); got != tt.want {
+			t.Errorf("Lerp(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestMoveTowards(t *testing.T) {
+	from, to := New(0, 0, 0), New(0, 0, 10)
+	if got := from.MoveTowards(to, 4); got != New(0, 0, 4) {
+		t.Fatalf("MoveTowards = %v, want 4 units along Z", got)
+	}
+	if got := from.MoveTowards(to, 20); got != to {
+		t.Fatalf("MoveTowards overshot to %v", got)
+	}
+}